@@ -98,6 +98,10 @@ func (bigIntCodec) RequiresTerminator() bool {
 	return false
 }
 
+func (c bigIntCodec) Describe() Description {
+	return Description{Kind: "big_int", NilsLast: isNilsLast(c.prefix)}
+}
+
 //lint:ignore U1000 this is actually used
 func (bigIntCodec) nilsLast() Codec[*big.Int] {
 	return bigIntCodec{PrefixNilsLast}
@@ -410,6 +414,10 @@ func (bigFloatCodec) RequiresTerminator() bool {
 	return false
 }
 
+func (c bigFloatCodec) Describe() Description {
+	return Description{Kind: "big_float", NilsLast: isNilsLast(c.prefix)}
+}
+
 //lint:ignore U1000 this is actually used
 func (bigFloatCodec) nilsLast() Codec[*big.Float] {
 	return bigFloatCodec{PrefixNilsLast}
@@ -462,6 +470,10 @@ func (bigRatCodec) RequiresTerminator() bool {
 	return false
 }
 
+func (c bigRatCodec) Describe() Description {
+	return Description{Kind: "big_rat", NilsLast: isNilsLast(c.prefix)}
+}
+
 //lint:ignore U1000 this is actually used
 func (bigRatCodec) nilsLast() Codec[*big.Rat] {
 	return bigRatCodec{PrefixNilsLast}
@@ -0,0 +1,158 @@
+package lexy
+
+// Codecs for []bool packed into bits, in the same order as SliceOf(Bool()).
+//
+// bitSetCodec encodes a slice of any length as:
+//
+//   - if nil, prefixNilFirst/Last
+//   - if non-nil, prefixNonNil followed by:
+//   - the elements in groups of 7, each group encoded as a byte with the high bit set,
+//     followed by the elements in order, with the last group padded with false
+//   - bitSetEnd
+//   - the number of elements in the last group, 1-7, or 0 if the slice is empty
+//
+// Because every group byte has its high bit set, bitSetEnd is less than any group byte,
+// so a slice is ordered before any longer slice it is a prefix of.
+// Comparing the packed bits is the same as comparing the elements lexicographically,
+// and the count of elements in the last group breaks any remaining tie, where the shorter slice is ordered first.
+// The end of the encoding is known from bitSetEnd, so this Codec does not require escaping.
+//
+// fixedBitSetCodec encodes a slice of at most size elements as exactly numBytes(size) bytes,
+// with the elements packed big-endian, padded with false.
+// Because the encoded length is fixed, this Codec does not require escaping.
+type (
+	bitSetCodec struct {
+		prefix Prefix
+	}
+
+	fixedBitSetCodec struct {
+		size int
+	}
+)
+
+const (
+	bitSetEnd      byte = 0x00
+	bitSetGroupBit byte = 0x80
+	bitsPerGroup        = 7
+)
+
+func bitSetSize(n int) int {
+	return (n+bitsPerGroup-1)/bitsPerGroup + 2 //nolint:mnd
+}
+
+func (c bitSetCodec) Append(buf []byte, value []bool) []byte {
+	done, buf := c.prefix.Append(buf, value == nil)
+	if done {
+		return buf
+	}
+	buf = append(buf, make([]byte, bitSetSize(len(value)))...)
+	putBitSet(buf[len(buf)-bitSetSize(len(value)):], value)
+	return buf
+}
+
+func (c bitSetCodec) Put(buf []byte, value []bool) []byte {
+	done, buf := c.prefix.Put(buf, value == nil)
+	if done {
+		return buf
+	}
+	return putBitSet(buf, value)
+}
+
+func putBitSet(buf []byte, value []bool) []byte {
+	size := bitSetSize(len(value))
+	_ = buf[size-1] // check that we have room
+	clear(buf[:size])
+	for i, bit := range value {
+		group := i / bitsPerGroup
+		buf[group] |= bitSetGroupBit
+		if bit {
+			buf[group] |= bitSetGroupBit >> (1 + i%bitsPerGroup)
+		}
+	}
+	buf[size-2] = bitSetEnd
+	buf[size-1] = byte(len(value) % bitsPerGroup)
+	if len(value) > 0 && buf[size-1] == 0 {
+		buf[size-1] = bitsPerGroup
+	}
+	return buf[size:]
+}
+
+func (c bitSetCodec) Get(buf []byte) ([]bool, []byte) {
+	done, buf := c.prefix.Get(buf)
+	if done {
+		return nil, buf
+	}
+	numGroups := 0
+	for buf[numGroups] != bitSetEnd {
+		numGroups++
+	}
+	count := int(buf[numGroups+1])
+	if count > bitsPerGroup || (count == 0) != (numGroups == 0) {
+		panic(errBadBitSetEncoding)
+	}
+	value := make([]bool, 0, numGroups*bitsPerGroup)
+	for i := range numGroups*bitsPerGroup - bitsPerGroup + count {
+		value = append(value, buf[i/bitsPerGroup]&(bitSetGroupBit>>(1+i%bitsPerGroup)) != 0)
+	}
+	return value, buf[numGroups+2:]
+}
+
+func (bitSetCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (c bitSetCodec) Describe() Description {
+	return Description{Kind: "bit_set", NilsLast: isNilsLast(c.prefix)}
+}
+
+//lint:ignore U1000 this is actually used
+func (bitSetCodec) nilsLast() Codec[[]bool] {
+	return bitSetCodec{PrefixNilsLast}
+}
+
+func (c fixedBitSetCodec) Append(buf []byte, value []bool) []byte {
+	size := numBytes(c.size)
+	buf = append(buf, make([]byte, size)...)
+	c.Put(buf[len(buf)-size:], value)
+	return buf
+}
+
+func (c fixedBitSetCodec) Put(buf []byte, value []bool) []byte {
+	if len(value) > c.size {
+		panic(bitSetLengthError{c.size, len(value)})
+	}
+	size := numBytes(c.size)
+	if size == 0 {
+		return buf
+	}
+	_ = buf[size-1] // check that we have room
+	clear(buf[:size])
+	for i, bit := range value {
+		if bit {
+			buf[i/bitsPerByte] |= 0x80 >> (i % bitsPerByte)
+		}
+	}
+	return buf[size:]
+}
+
+func (c fixedBitSetCodec) Get(buf []byte) ([]bool, []byte) {
+	size := numBytes(c.size)
+	if size == 0 {
+		return []bool{}, buf
+	}
+	_ = buf[size-1] // check that we have enough
+	value := make([]bool, c.size)
+	for i := range value {
+		value[i] = buf[i/bitsPerByte]&(0x80>>(i%bitsPerByte)) != 0
+	}
+	return value, buf[size:]
+}
+
+func (c fixedBitSetCodec) RequiresTerminator() bool {
+	// Every encoding is empty if size is 0, otherwise the encoded length is fixed.
+	return c.size == 0
+}
+
+func (c fixedBitSetCodec) Describe() Description {
+	return Description{Kind: "fixed_bit_set", Params: []any{c.size}}
+}
@@ -0,0 +1,173 @@
+package lexy_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+func bits(s string) []bool {
+	value := []bool{}
+	for _, c := range s {
+		value = append(value, c == '1')
+	}
+	return value
+}
+
+func TestBitSet(t *testing.T) {
+	t.Parallel()
+	codec := lexy.BitSet()
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[[]bool]{
+		{"nil", nil, []byte{pNilFirst}},
+		{"empty", bits(""), []byte{pNonNil, 0x00, 0x00}},
+		{"0", bits("0"), []byte{pNonNil, 0x80, 0x00, 0x01}},
+		{"1", bits("1"), []byte{pNonNil, 0xC0, 0x00, 0x01}},
+		{"101", bits("101"), []byte{pNonNil, 0xD0, 0x00, 0x03}},
+		{"0000000", bits("0000000"), []byte{pNonNil, 0x80, 0x00, 0x07}},
+		{"1111111", bits("1111111"), []byte{pNonNil, 0xFF, 0x00, 0x07}},
+		{"11111110", bits("11111110"), []byte{pNonNil, 0xFF, 0x80, 0x00, 0x01}},
+		{"000000001", bits("000000001"), []byte{pNonNil, 0x80, 0xA0, 0x00, 0x02}},
+	})
+	testCodec(t, lexy.NilsLast(codec), []testCase[[]bool]{
+		{"nil", nil, []byte{pNilLast}},
+		{"1", bits("1"), []byte{pNonNil, 0xC0, 0x00, 0x01}},
+	})
+}
+
+func TestBitSetOrdering(t *testing.T) {
+	t.Parallel()
+	testOrdering(t, lexy.BitSet(), []testCase[[]bool]{
+		{"nil", nil, nil},
+		{"empty", bits(""), nil},
+		{"0", bits("0"), nil},
+		{"0000000", bits("0000000"), nil},
+		{"00000000", bits("00000000"), nil},
+		{"000000001", bits("000000001"), nil},
+		{"0000001", bits("0000001"), nil},
+		{"01", bits("01"), nil},
+		{"1", bits("1"), nil},
+		{"10", bits("10"), nil},
+		{"1111111", bits("1111111"), nil},
+		{"11111110", bits("11111110"), nil},
+		{"11111111", bits("11111111"), nil},
+	})
+}
+
+// Every slice of up to maxLen elements, in no particular order.
+func allBitSets(maxLen int) [][]bool {
+	result := [][]bool{nil}
+	for n := range maxLen + 1 {
+		for mask := range 1 << n {
+			value := make([]bool, n)
+			for i := range value {
+				value[i] = mask&(1<<i) != 0
+			}
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func TestBitSetMatchesSliceOfBool(t *testing.T) {
+	t.Parallel()
+	for _, nilsLast := range []bool{false, true} {
+		codec := lexy.BitSet()
+		sliceCodec := lexy.SliceOf(lexy.Bool())
+		if nilsLast {
+			codec = lexy.NilsLast(codec)
+			sliceCodec = lexy.NilsLast(sliceCodec)
+		}
+		values := allBitSets(12)
+		slices.SortFunc(values, func(a, b []bool) int {
+			return bytes.Compare(sliceCodec.Append(nil, a), sliceCodec.Append(nil, b))
+		})
+		var prev []byte
+		for i, value := range values {
+			buf := codec.Append(nil, value)
+			got, rest := codec.Get(buf)
+			require.Equal(t, value, got)
+			require.Empty(t, rest)
+			if i > 0 {
+				require.Negative(t, bytes.Compare(prev, buf), "%v %v", values[i-1], value)
+			}
+			prev = buf
+		}
+	}
+}
+
+func TestBitSetBadEncoding(t *testing.T) {
+	t.Parallel()
+	codec := lexy.BitSet()
+	for _, buf := range [][]byte{
+		{pNonNil, 0x00, 0x01},
+		{pNonNil, 0x80, 0x00, 0x00},
+		{pNonNil, 0x80, 0x00, 0x08},
+	} {
+		assert.PanicsWithError(t, "invalid bit set encoding", func() {
+			codec.Get(buf)
+		})
+	}
+	assert.Panics(t, func() {
+		codec.Get([]byte{pNonNil, 0x80})
+	})
+}
+
+func TestFixedBitSet(t *testing.T) {
+	t.Parallel()
+	codec := lexy.FixedBitSet(10)
+	assert.False(t, codec.RequiresTerminator())
+	for _, tt := range []struct {
+		name  string
+		value []bool
+		data  []byte
+	}{
+		{"nil", nil, []byte{0x00, 0x00}},
+		{"1", bits("1"), []byte{0x80, 0x00}},
+		{"0000000001", bits("0000000001"), []byte{0x00, 0x40}},
+		{"1010101011", bits("1010101011"), []byte{0xAA, 0xC0}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			buf := codec.Append(nil, tt.value)
+			assert.Equal(t, tt.data, buf)
+			put := make([]byte, 2)
+			assert.Empty(t, codec.Put(put, tt.value))
+			assert.Equal(t, tt.data, put)
+			got, rest := codec.Get(append(buf, 0xFF))
+			assert.Equal(t, append(tt.value, make([]bool, 10-len(tt.value))...), got)
+			assert.Equal(t, []byte{0xFF}, rest)
+		})
+	}
+	testCodec(t, codec, []testCase[[]bool]{
+		{"0000000000", bits("0000000000"), []byte{0x00, 0x00}},
+		{"1111111111", bits("1111111111"), []byte{0xFF, 0xC0}},
+	})
+	testOrdering(t, codec, []testCase[[]bool]{
+		{"0000000000", bits("0000000000"), nil},
+		{"0000000001", bits("0000000001"), nil},
+		{"0100000000", bits("0100000000"), nil},
+		{"1000000000", bits("1000000000"), nil},
+		{"1111111111", bits("1111111111"), nil},
+	})
+	assert.PanicsWithError(t, "bit set of length 11 is longer than 10", func() {
+		codec.Append(nil, make([]bool, 11))
+	})
+	assert.Panics(t, func() {
+		lexy.FixedBitSet(-1)
+	})
+}
+
+func TestFixedBitSetEmpty(t *testing.T) {
+	t.Parallel()
+	codec := lexy.FixedBitSet(0)
+	assert.True(t, codec.RequiresTerminator())
+	assert.Empty(t, codec.Append(nil, nil))
+	got, _ := codec.Get(nil)
+	assert.Equal(t, []bool{}, got)
+}
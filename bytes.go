@@ -37,6 +37,10 @@ func (bytesCodec) RequiresTerminator() bool {
 	return true
 }
 
+func (c bytesCodec) Describe() Description {
+	return Description{Kind: "bytes", NilsLast: isNilsLast(c.prefix)}
+}
+
 //lint:ignore U1000 this is actually used
 func (bytesCodec) nilsLast() Codec[[]byte] {
 	return bytesCodec{PrefixNilsLast}
@@ -137,6 +137,10 @@ func (castBool[T]) RequiresTerminator() bool {
 	return stdBool.RequiresTerminator()
 }
 
+func (castBool[T]) Describe() Description {
+	return stdBool.Describe()
+}
+
 func (castUint8[T]) Append(buf []byte, value T) []byte {
 	return stdUint8.Append(buf, uint8(value))
 }
@@ -154,6 +158,10 @@ func (castUint8[T]) RequiresTerminator() bool {
 	return stdUint8.RequiresTerminator()
 }
 
+func (castUint8[T]) Describe() Description {
+	return stdUint8.Describe()
+}
+
 func (castUint16[T]) Append(buf []byte, value T) []byte {
 	return stdUint16.Append(buf, uint16(value))
 }
@@ -171,6 +179,10 @@ func (castUint16[T]) RequiresTerminator() bool {
 	return stdUint16.RequiresTerminator()
 }
 
+func (castUint16[T]) Describe() Description {
+	return stdUint16.Describe()
+}
+
 func (castUint32[T]) Append(buf []byte, value T) []byte {
 	return stdUint32.Append(buf, uint32(value))
 }
@@ -188,6 +200,10 @@ func (castUint32[T]) RequiresTerminator() bool {
 	return stdUint32.RequiresTerminator()
 }
 
+func (castUint32[T]) Describe() Description {
+	return stdUint32.Describe()
+}
+
 func (castUint64[T]) Append(buf []byte, value T) []byte {
 	return stdUint64.Append(buf, uint64(value))
 }
@@ -205,6 +221,10 @@ func (castUint64[T]) RequiresTerminator() bool {
 	return stdUint64.RequiresTerminator()
 }
 
+func (castUint64[T]) Describe() Description {
+	return stdUint64.Describe()
+}
+
 func (castInt8[T]) Append(buf []byte, value T) []byte {
 	return stdInt8.Append(buf, int8(value))
 }
@@ -222,6 +242,10 @@ func (castInt8[T]) RequiresTerminator() bool {
 	return stdInt8.RequiresTerminator()
 }
 
+func (castInt8[T]) Describe() Description {
+	return stdInt8.Describe()
+}
+
 func (castInt16[T]) Append(buf []byte, value T) []byte {
 	return stdInt16.Append(buf, int16(value))
 }
@@ -239,6 +263,10 @@ func (castInt16[T]) RequiresTerminator() bool {
 	return stdInt16.RequiresTerminator()
 }
 
+func (castInt16[T]) Describe() Description {
+	return stdInt16.Describe()
+}
+
 func (castInt32[T]) Append(buf []byte, value T) []byte {
 	return stdInt32.Append(buf, int32(value))
 }
@@ -256,6 +284,10 @@ func (castInt32[T]) RequiresTerminator() bool {
 	return stdInt32.RequiresTerminator()
 }
 
+func (castInt32[T]) Describe() Description {
+	return stdInt32.Describe()
+}
+
 func (castInt64[T]) Append(buf []byte, value T) []byte {
 	return stdInt64.Append(buf, int64(value))
 }
@@ -273,6 +305,10 @@ func (castInt64[T]) RequiresTerminator() bool {
 	return stdInt64.RequiresTerminator()
 }
 
+func (castInt64[T]) Describe() Description {
+	return stdInt64.Describe()
+}
+
 func (castFloat32[T]) Append(buf []byte, value T) []byte {
 	return stdFloat32.Append(buf, float32(value))
 }
@@ -290,6 +326,10 @@ func (castFloat32[T]) RequiresTerminator() bool {
 	return stdFloat32.RequiresTerminator()
 }
 
+func (castFloat32[T]) Describe() Description {
+	return stdFloat32.Describe()
+}
+
 func (castFloat64[T]) Append(buf []byte, value T) []byte {
 	return stdFloat64.Append(buf, float64(value))
 }
@@ -307,6 +347,10 @@ func (castFloat64[T]) RequiresTerminator() bool {
 	return stdFloat64.RequiresTerminator()
 }
 
+func (castFloat64[T]) Describe() Description {
+	return stdFloat64.Describe()
+}
+
 func (castString[T]) Append(buf []byte, value T) []byte {
 	return stdString.Append(buf, string(value))
 }
@@ -324,6 +368,10 @@ func (castString[T]) RequiresTerminator() bool {
 	return stdString.RequiresTerminator()
 }
 
+func (castString[T]) Describe() Description {
+	return stdString.Describe()
+}
+
 func (c castBytes[T]) Append(buf []byte, value T) []byte {
 	return c.codec.Append(buf, []byte(value))
 }
@@ -340,6 +388,10 @@ func (c castBytes[T]) RequiresTerminator() bool {
 	return c.codec.RequiresTerminator()
 }
 
+func (c castBytes[T]) Describe() Description {
+	return c.codec.Describe()
+}
+
 //lint:ignore U1000 this is actually used
 func (c castBytes[T]) nilsLast() Codec[T] {
 	//nolint:errcheck,forcetypeassert
@@ -362,6 +414,14 @@ func (c castPointer[P, E]) RequiresTerminator() bool {
 	return c.codec.RequiresTerminator()
 }
 
+func (c castPointer[P, E]) Describe() Description {
+	return c.codec.Describe()
+}
+
+func (c castPointer[P, E]) format(f *formatState, buf []byte) []byte {
+	return c.codec.format(f, buf)
+}
+
 //lint:ignore U1000 this is actually used
 func (c castPointer[P, E]) nilsLast() Codec[P] {
 	//nolint:errcheck,forcetypeassert
@@ -384,6 +444,14 @@ func (c castSlice[S, E]) RequiresTerminator() bool {
 	return c.codec.RequiresTerminator()
 }
 
+func (c castSlice[S, E]) Describe() Description {
+	return c.codec.Describe()
+}
+
+func (c castSlice[S, E]) format(f *formatState, buf []byte) []byte {
+	return c.codec.format(f, buf)
+}
+
 //lint:ignore U1000 this is actually used
 func (c castSlice[S, E]) nilsLast() Codec[S] {
 	//nolint:errcheck,forcetypeassert
@@ -406,6 +474,14 @@ func (c castMap[M, K, V]) RequiresTerminator() bool {
 	return c.codec.RequiresTerminator()
 }
 
+func (c castMap[M, K, V]) Describe() Description {
+	return c.codec.Describe()
+}
+
+func (c castMap[M, K, V]) format(f *formatState, buf []byte) []byte {
+	return c.codec.format(f, buf)
+}
+
 //lint:ignore U1000 this is actually used
 func (c castMap[M, K, V]) nilsLast() Codec[M] {
 	//nolint:errcheck,forcetypeassert
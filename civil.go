@@ -0,0 +1,193 @@
+package lexy
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Date is a civil date, a year, month, and day without a time of day or location.
+//
+// A Date is normalized the same way [time.Date] normalizes its arguments,
+// so October 32 is the same Date as November 1.
+// Codecs for Date only round trip normalized values.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// TimeOfDay is a civil time of day, without a date or location.
+//
+// A TimeOfDay is expected to be within the range 00:00:00 to 23:59:59.999999999.
+// Codecs for TimeOfDay only round trip values in that range with every field in its normal range.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// DateTime is a civil date and time of day, without a location.
+type DateTime struct {
+	Date
+	TimeOfDay
+}
+
+// DateOf returns the Date on which t occurs in t's location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{year, month, day}
+}
+
+// TimeOfDayOf returns the TimeOfDay at which t occurs in t's location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	hour, minute, second := t.Clock()
+	return TimeOfDay{hour, minute, second, t.Nanosecond()}
+}
+
+// DateTimeOf returns the DateTime at which t occurs in t's location.
+func DateTimeOf(t time.Time) DateTime {
+	return DateTime{DateOf(t), TimeOfDayOf(t)}
+}
+
+// In returns the time.Time at the start of d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// String returns d in the form "2006-01-02".
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// String returns t in the form "15:04:05.999999999", omitting trailing zeros of the fractional second.
+func (t TimeOfDay) String() string {
+	return time.Date(0, 1, 1, t.Hour, t.Minute, t.Second, t.Nanosecond, time.UTC).Format("15:04:05.999999999")
+}
+
+// In returns the time.Time at dt in loc.
+func (dt DateTime) In(loc *time.Location) time.Time {
+	return time.Date(dt.Year, dt.Month, dt.Day, dt.Hour, dt.Minute, dt.Second, dt.Nanosecond, loc)
+}
+
+// String returns dt in the form "2006-01-02T15:04:05.999999999".
+func (dt DateTime) String() string {
+	return dt.Date.String() + "T" + dt.TimeOfDay.String()
+}
+
+// Codecs for civil dates and times of day.
+//
+// A Date is encoded as an int32 number of days since 1970-01-01.
+// The result is undefined if that number does not fit in an int32,
+// which can only happen for dates more than 5.8 million years from 1970.
+//
+// A TimeOfDay is encoded as a uint64 number of nanoseconds since midnight.
+//
+// A DateTime is encoded as its Date followed by its TimeOfDay.
+type (
+	dateCodec      struct{}
+	timeOfDayCodec struct{}
+	dateTimeCodec  struct{}
+)
+
+const (
+	secondsPerDay = 24 * 60 * 60
+	nanosPerHour  = int64(time.Hour)
+	nanosPerMin   = int64(time.Minute)
+)
+
+func dateToDays(value Date) int32 {
+	//nolint:gosec  // documented as undefined if this overflows
+	return int32(value.In(time.UTC).Unix() / secondsPerDay)
+}
+
+func dateFromDays(days int32) Date {
+	return DateOf(time.Unix(int64(days)*secondsPerDay, 0).UTC())
+}
+
+func timeOfDayToNanos(value TimeOfDay) uint64 {
+	//nolint:gosec  // documented as undefined if out of range
+	return uint64(int64(value.Hour)*nanosPerHour +
+		int64(value.Minute)*nanosPerMin +
+		int64(value.Second)*nanosPerSecond +
+		int64(value.Nanosecond))
+}
+
+func timeOfDayFromNanos(nanos uint64) TimeOfDay {
+	//nolint:gosec  // will not overflow for valid encodings
+	n := int64(nanos)
+	return TimeOfDay{
+		int(n / nanosPerHour),
+		int(n % nanosPerHour / nanosPerMin),
+		int(n % nanosPerMin / nanosPerSecond),
+		int(n % nanosPerSecond),
+	}
+}
+
+func (dateCodec) Append(buf []byte, value Date) []byte {
+	return stdInt32.Append(buf, dateToDays(value))
+}
+
+func (dateCodec) Put(buf []byte, value Date) []byte {
+	return stdInt32.Put(buf, dateToDays(value))
+}
+
+func (dateCodec) Get(buf []byte) (Date, []byte) {
+	days, buf := stdInt32.Get(buf)
+	return dateFromDays(days), buf
+}
+
+func (dateCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (dateCodec) Describe() Description {
+	return Description{Kind: "date"}
+}
+
+func (timeOfDayCodec) Append(buf []byte, value TimeOfDay) []byte {
+	return stdUint64.Append(buf, timeOfDayToNanos(value))
+}
+
+func (timeOfDayCodec) Put(buf []byte, value TimeOfDay) []byte {
+	return stdUint64.Put(buf, timeOfDayToNanos(value))
+}
+
+func (timeOfDayCodec) Get(buf []byte) (TimeOfDay, []byte) {
+	nanos, buf := stdUint64.Get(buf)
+	return timeOfDayFromNanos(nanos), buf
+}
+
+func (timeOfDayCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (timeOfDayCodec) Describe() Description {
+	return Description{Kind: "time_of_day"}
+}
+
+func (dateTimeCodec) Append(buf []byte, value DateTime) []byte {
+	//nolint:mnd
+	buf = stdDate.Append(slices.Grow(buf, 12), value.Date)
+	return stdTimeOfDay.Append(buf, value.TimeOfDay)
+}
+
+func (dateTimeCodec) Put(buf []byte, value DateTime) []byte {
+	buf = stdDate.Put(buf, value.Date)
+	return stdTimeOfDay.Put(buf, value.TimeOfDay)
+}
+
+func (dateTimeCodec) Get(buf []byte) (DateTime, []byte) {
+	date, buf := stdDate.Get(buf)
+	timeOfDay, buf := stdTimeOfDay.Get(buf)
+	return DateTime{date, timeOfDay}, buf
+}
+
+func (dateTimeCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (dateTimeCodec) Describe() Description {
+	return Description{Kind: "date_time"}
+}
@@ -0,0 +1,111 @@
+package lexy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+func TestCivilDate(t *testing.T) {
+	t.Parallel()
+	codec := lexy.CivilDate()
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.Date]{
+		{"epoch", lexy.Date{1970, time.January, 1}, []byte{0x80, 0x00, 0x00, 0x00}},
+		{"day before epoch", lexy.Date{1969, time.December, 31}, []byte{0x7F, 0xFF, 0xFF, 0xFF}},
+		{"day after epoch", lexy.Date{1970, time.January, 2}, []byte{0x80, 0x00, 0x00, 0x01}},
+		{"leap day", lexy.Date{2000, time.February, 29}, []byte{0x80, 0x00, 0x2B, 0x08}},
+		{"year 1", lexy.Date{1, time.January, 1}, []byte{0x7F, 0xF5, 0x06, 0xC6}},
+	})
+}
+
+func TestCivilDateNormalized(t *testing.T) {
+	t.Parallel()
+	codec := lexy.CivilDate()
+	got, _ := codec.Get(codec.Append(nil, lexy.Date{2001, time.February, 29}))
+	assert.Equal(t, lexy.Date{2001, time.March, 1}, got)
+}
+
+func TestCivilDateOrdering(t *testing.T) {
+	t.Parallel()
+	testOrdering(t, lexy.CivilDate(), []testCase[lexy.Date]{
+		{"-1000-06-15", lexy.Date{-1000, time.June, 15}, nil},
+		{"1900-01-01", lexy.Date{1900, time.January, 1}, nil},
+		{"1969-12-31", lexy.Date{1969, time.December, 31}, nil},
+		{"1970-01-01", lexy.Date{1970, time.January, 1}, nil},
+		{"1970-01-02", lexy.Date{1970, time.January, 2}, nil},
+		{"2000-02-28", lexy.Date{2000, time.February, 28}, nil},
+		{"2000-02-29", lexy.Date{2000, time.February, 29}, nil},
+		{"2000-03-01", lexy.Date{2000, time.March, 1}, nil},
+		{"9999-12-31", lexy.Date{9999, time.December, 31}, nil},
+	})
+}
+
+func TestCivilTimeOfDay(t *testing.T) {
+	t.Parallel()
+	codec := lexy.CivilTimeOfDay()
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.TimeOfDay]{
+		{"midnight", lexy.TimeOfDay{}, []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+		{"1ns", lexy.TimeOfDay{0, 0, 0, 1}, []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+		{"1s", lexy.TimeOfDay{0, 0, 1, 0}, []byte{0, 0, 0, 0, 0x3B, 0x9A, 0xCA, 0x00}},
+		{"last", lexy.TimeOfDay{23, 59, 59, 999_999_999}, []byte{0, 0, 0x4E, 0x94, 0x91, 0x4E, 0xFF, 0xFF}},
+	})
+	testOrdering(t, codec, []testCase[lexy.TimeOfDay]{
+		{"midnight", lexy.TimeOfDay{0, 0, 0, 0}, nil},
+		{"00:00:00.000000001", lexy.TimeOfDay{0, 0, 0, 1}, nil},
+		{"00:00:01", lexy.TimeOfDay{0, 0, 1, 0}, nil},
+		{"00:01:00", lexy.TimeOfDay{0, 1, 0, 0}, nil},
+		{"01:00:00", lexy.TimeOfDay{1, 0, 0, 0}, nil},
+		{"12:34:56.7", lexy.TimeOfDay{12, 34, 56, 700_000_000}, nil},
+		{"23:59:59.999999999", lexy.TimeOfDay{23, 59, 59, 999_999_999}, nil},
+	})
+}
+
+func TestCivilDateTime(t *testing.T) {
+	t.Parallel()
+	codec := lexy.CivilDateTime()
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.DateTime]{
+		{"epoch", lexy.DateTime{lexy.Date{1970, time.January, 1}, lexy.TimeOfDay{}}, []byte{
+			0x80, 0x00, 0x00, 0x00,
+			0, 0, 0, 0, 0, 0, 0, 0,
+		}},
+		{"before epoch", lexy.DateTime{lexy.Date{1969, time.December, 31}, lexy.TimeOfDay{0, 0, 1, 0}}, []byte{
+			0x7F, 0xFF, 0xFF, 0xFF,
+			0, 0, 0, 0, 0x3B, 0x9A, 0xCA, 0x00,
+		}},
+	})
+	testOrdering(t, codec, []testCase[lexy.DateTime]{
+		{"1969-12-31T23:59:59", lexy.DateTime{lexy.Date{1969, time.December, 31}, lexy.TimeOfDay{23, 59, 59, 0}}, nil},
+		{"1970-01-01T00:00:00", lexy.DateTime{lexy.Date{1970, time.January, 1}, lexy.TimeOfDay{}}, nil},
+		{"1970-01-01T00:00:01", lexy.DateTime{lexy.Date{1970, time.January, 1}, lexy.TimeOfDay{0, 0, 1, 0}}, nil},
+		{"1970-01-02T00:00:00", lexy.DateTime{lexy.Date{1970, time.January, 2}, lexy.TimeOfDay{}}, nil},
+	})
+}
+
+func TestCivilConversions(t *testing.T) {
+	t.Parallel()
+	locNYC, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// 2000-01-02 03:04:05 UTC is still 2000-01-01 in New York.
+	when := time.Date(2000, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	assert.Equal(t, lexy.Date{2000, time.January, 2}, lexy.DateOf(when))
+	assert.Equal(t, lexy.Date{2000, time.January, 1}, lexy.DateOf(when.In(locNYC)))
+	assert.Equal(t, lexy.TimeOfDay{3, 4, 5, 6}, lexy.TimeOfDayOf(when))
+	assert.Equal(t, lexy.TimeOfDay{22, 4, 5, 6}, lexy.TimeOfDayOf(when.In(locNYC)))
+
+	dt := lexy.DateTimeOf(when.In(locNYC))
+	assert.Equal(t, "2000-01-01T22:04:05.000000006", dt.String())
+	assert.True(t, when.Equal(dt.In(locNYC)))
+	assert.True(t, time.Date(2000, 1, 1, 0, 0, 0, 0, locNYC).Equal(dt.Date.In(locNYC)))
+
+	assert.Equal(t, "0987-06-05", lexy.Date{987, time.June, 5}.String())
+	assert.Equal(t, "01:02:03", lexy.TimeOfDay{1, 2, 3, 0}.String())
+	assert.Equal(t, "01:02:03.4", lexy.TimeOfDay{1, 2, 3, 400_000_000}.String())
+}
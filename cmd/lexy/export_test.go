@@ -0,0 +1,8 @@
+package main
+
+var Run = run
+
+// ProcessArg calls processArg with a process function which calls f.
+func ProcessArg(f func() error) error {
+	return processArg(func(*command, string) error { return f() }, nil, "")
+}
@@ -0,0 +1,405 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phiryll/lexy"
+)
+
+var (
+	errJSONType  = errors.New("wrong JSON type")
+	errJSONValue = errors.New("invalid value")
+)
+
+// Layouts for the civil types, matching their String methods.
+const (
+	dateLayout      = "2006-01-02"
+	timeOfDayLayout = "15:04:05.999999999"
+	dateTimeLayout  = dateLayout + "T" + timeOfDayLayout
+)
+
+func typeError(desc lexy.Description, value any) error {
+	return fmt.Errorf("%w for %s: %T", errJSONType, desc.Kind, value)
+}
+
+// fromJSON converts value, decoded by a json.Decoder using UseNumber,
+// to the value encoded by the Codec returned by ParseSchema(desc.String()).
+func fromJSON(desc lexy.Description, value any) (any, error) {
+	switch desc.Kind {
+	case "slice", "shortlex_slice", "bit_set", "bytes", "shortlex_bytes", "map", "optional",
+		"big_int", "big_float", "big_rat":
+		if value == nil {
+			return nilOf(desc), nil
+		}
+	}
+	switch desc.Kind {
+	case "bool":
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case "uint8", "uint16", "uint32", "uint64":
+		return uintFromJSON(desc, value)
+	case "int8", "int16", "int32", "int64":
+		return intFromJSON(desc, value)
+	case "float32", "float64":
+		return floatFromJSON(desc, value)
+	case "complex64", "complex128":
+		return complexFromJSON(desc, value)
+	case "string", "natural_string", "folded_string", "shortlex_string", "domain_name":
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case "bytes", "shortlex_bytes":
+		if s, ok := value.(string); ok {
+			return base64.StdEncoding.DecodeString(s)
+		}
+	case "bit_set":
+		return bitSetFromJSON(desc, value)
+	case "time", "time_utc", "time_seconds", "time_millis", "time_micros", "time_zoned",
+		"date", "time_of_day", "date_time", "semver", "big_int", "big_float", "big_rat":
+		if s, ok := value.(string); ok {
+			return parseString(desc, s)
+		}
+	case "slice", "shortlex_slice", "tuple":
+		return sliceFromJSON(desc, value)
+	case "map":
+		return mapFromJSON(desc, value)
+	case "optional":
+		return fromJSON(*desc.Elem, value)
+	default:
+		return nil, fmt.Errorf("%w: unsupported kind %s", errJSONValue, desc.Kind)
+	}
+	return nil, typeError(desc, value)
+}
+
+// nilOf returns the nil value for desc's Kind, as the Codec returned by ParseSchema expects it.
+func nilOf(desc lexy.Description) any {
+	switch desc.Kind {
+	case "slice", "shortlex_slice":
+		return []any(nil)
+	case "bit_set":
+		return []bool(nil)
+	case "bytes", "shortlex_bytes":
+		return []byte(nil)
+	case "map":
+		return map[any]any(nil)
+	case "big_int":
+		return (*big.Int)(nil)
+	case "big_float":
+		return (*big.Float)(nil)
+	case "big_rat":
+		return (*big.Rat)(nil)
+	default:
+		return nil
+	}
+}
+
+func number(desc lexy.Description, value any) (string, error) {
+	if n, ok := value.(json.Number); ok {
+		return string(n), nil
+	}
+	return "", typeError(desc, value)
+}
+
+func uintFromJSON(desc lexy.Description, value any) (any, error) {
+	s, err := number(desc, value)
+	if err != nil {
+		return nil, err
+	}
+	switch desc.Kind {
+	case "uint8":
+		n, err := strconv.ParseUint(s, 10, 8)
+		return uint8(n), err
+	case "uint16":
+		n, err := strconv.ParseUint(s, 10, 16)
+		return uint16(n), err
+	case "uint32":
+		n, err := strconv.ParseUint(s, 10, 32)
+		return uint32(n), err
+	default:
+		return strconv.ParseUint(s, 10, 64)
+	}
+}
+
+func intFromJSON(desc lexy.Description, value any) (any, error) {
+	s, err := number(desc, value)
+	if err != nil {
+		return nil, err
+	}
+	switch desc.Kind {
+	case "int8":
+		n, err := strconv.ParseInt(s, 10, 8)
+		return int8(n), err
+	case "int16":
+		n, err := strconv.ParseInt(s, 10, 16)
+		return int16(n), err
+	case "int32":
+		n, err := strconv.ParseInt(s, 10, 32)
+		return int32(n), err
+	default:
+		return strconv.ParseInt(s, 10, 64)
+	}
+}
+
+// floatFromJSON also accepts the strings "NaN", "+Inf", and "-Inf", which JSON numbers cannot represent.
+func floatFromJSON(desc lexy.Description, value any) (any, error) {
+	var s string
+	switch v := value.(type) {
+	case json.Number:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return nil, typeError(desc, value)
+	}
+	if desc.Kind == "float32" {
+		f, err := strconv.ParseFloat(s, 32)
+		return float32(f), err
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func complexFromJSON(desc lexy.Description, value any) (any, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, typeError(desc, value)
+	}
+	if desc.Kind == "complex64" {
+		c, err := strconv.ParseComplex(s, 64)
+		return complex64(c), err
+	}
+	return strconv.ParseComplex(s, 128)
+}
+
+func bitSetFromJSON(desc lexy.Description, value any) (any, error) {
+	elems, ok := value.([]any)
+	if !ok {
+		return nil, typeError(desc, value)
+	}
+	bits := make([]bool, len(elems))
+	for i, elem := range elems {
+		if bits[i], ok = elem.(bool); !ok {
+			return nil, typeError(desc, elem)
+		}
+	}
+	return bits, nil
+}
+
+func parseString(desc lexy.Description, s string) (any, error) {
+	switch desc.Kind {
+	case "date":
+		t, err := time.Parse(dateLayout, s)
+		return lexy.DateOf(t), err
+	case "time_of_day":
+		t, err := time.Parse(timeOfDayLayout, s)
+		return lexy.TimeOfDayOf(t), err
+	case "date_time":
+		t, err := time.Parse(dateTimeLayout, s)
+		return lexy.DateTimeOf(t), err
+	case "semver":
+		return lexy.ParseVersion(s)
+	case "big_int":
+		if n, ok := new(big.Int).SetString(s, 10); ok {
+			return n, nil
+		}
+	case "big_float":
+		if f, _, err := big.ParseFloat(s, 10, bigFloatPrec(s), big.ToNearestEven); err == nil {
+			return f, nil
+		}
+	case "big_rat":
+		if r, ok := new(big.Rat).SetString(s); ok {
+			return r, nil
+		}
+	default:
+		return time.Parse(time.RFC3339Nano, s)
+	}
+	return nil, fmt.Errorf("%w for %s: %q", errJSONValue, desc.Kind, s)
+}
+
+// minBigFloatPrec is the least precision of a parsed big.Float, the precision big.ParseFloat uses by default.
+const minBigFloatPrec = 64
+
+// bigFloatPrec returns the precision for parsing s as a big.Float, enough that its decimal digits round trip.
+func bigFloatPrec(s string) uint {
+	mantissa, _, _ := strings.Cut(strings.ToLower(s), "e")
+	digits := 0
+	for _, r := range mantissa {
+		if '0' <= r && r <= '9' {
+			digits++
+		}
+	}
+	// Each digit needs log2(10) bits, and one more bit ensures the shortest decimal form is the same digits.
+	return max(minBigFloatPrec, uint(math.Ceil(float64(digits)*math.Log2(10)))+1)
+}
+
+func sliceFromJSON(desc lexy.Description, value any) (any, error) {
+	elems, ok := value.([]any)
+	if !ok {
+		return nil, typeError(desc, value)
+	}
+	if desc.Kind == "tuple" && len(elems) != len(desc.Elems) {
+		return nil, fmt.Errorf("%w: tuple of length %d must have length %d", errJSONValue, len(elems), len(desc.Elems))
+	}
+	result := make([]any, len(elems))
+	for i, elem := range elems {
+		elemDesc := desc.Elem
+		if desc.Kind == "tuple" {
+			elemDesc = &desc.Elems[i]
+		}
+		var err error
+		if result[i], err = fromJSON(*elemDesc, elem); err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	return result, nil
+}
+
+// mapFromJSON converts a JSON object to a map.
+// Keys are converted from the JSON string if possible, and otherwise from the JSON value the string contains.
+func mapFromJSON(desc lexy.Description, value any) (any, error) {
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return nil, typeError(desc, value)
+	}
+	result := make(map[any]any, len(obj))
+	for k, v := range obj {
+		key, err := fromJSON(*desc.Key, k)
+		if err != nil {
+			var keyValue any
+			if jsonErr := unmarshal([]byte(k), &keyValue); jsonErr != nil {
+				return nil, fmt.Errorf("key %q: %w", k, err)
+			}
+			if key, err = fromJSON(*desc.Key, keyValue); err != nil {
+				return nil, fmt.Errorf("key %q: %w", k, err)
+			}
+		}
+		if result[key], err = fromJSON(*desc.Value, v); err != nil {
+			return nil, fmt.Errorf("[%q]: %w", k, err)
+		}
+	}
+	return result, nil
+}
+
+// toJSON converts value, decoded by the Codec returned by ParseSchema(desc.String()),
+// to a value which json.Marshal encodes in the form fromJSON accepts.
+func toJSON(desc lexy.Description, value any) (any, error) {
+	if desc.Kind == "optional" {
+		return toJSON(*desc.Elem, value)
+	}
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case bool, string:
+		return v, nil
+	case uint8, uint16, uint32, uint64, int8, int16, int32, int64:
+		return json.Number(fmt.Sprint(v)), nil
+	case float32:
+		return floatToJSON(float64(v), 32), nil
+	case float64:
+		return floatToJSON(v, 64), nil
+	case complex64:
+		return strconv.FormatComplex(complex128(v), 'g', -1, 64), nil
+	case complex128:
+		return strconv.FormatComplex(v, 'g', -1, 128), nil
+	case []byte:
+		if v == nil {
+			return nil, nil
+		}
+		return base64.StdEncoding.EncodeToString(v), nil
+	case []bool:
+		if v == nil {
+			return nil, nil
+		}
+		return v, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case *big.Int:
+		return bigToJSON(v), nil
+	case *big.Float:
+		return bigToJSON(v), nil
+	case *big.Rat:
+		return bigToJSON(v), nil
+	case fmt.Stringer:
+		// Date, TimeOfDay, DateTime, and Version.
+		return v.String(), nil
+	case []any:
+		return sliceToJSON(desc, v)
+	case map[any]any:
+		return mapToJSON(desc, v)
+	default:
+		return nil, typeError(desc, value)
+	}
+}
+
+func floatToJSON(f float64, bitSize int) any {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, bitSize)
+	}
+	return json.Number(strconv.FormatFloat(f, 'g', -1, bitSize))
+}
+
+func bigToJSON[T interface {
+	*big.Int | *big.Float | *big.Rat
+	String() string
+}](value T) any {
+	if value == nil {
+		return nil
+	}
+	if f, ok := any(value).(*big.Float); ok {
+		return f.Text('g', -1)
+	}
+	return value.String()
+}
+
+func sliceToJSON(desc lexy.Description, value []any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	result := make([]any, len(value))
+	for i, elem := range value {
+		elemDesc := desc.Elem
+		if desc.Kind == "tuple" {
+			elemDesc = &desc.Elems[i]
+		}
+		var err error
+		if result[i], err = toJSON(*elemDesc, elem); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// mapToJSON converts a map to a JSON object.
+// Keys which are not converted to JSON strings are written as the JSON text of the key.
+func mapToJSON(desc lexy.Description, value map[any]any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	result := make(map[string]any, len(value))
+	for k, v := range value {
+		key, err := toJSON(*desc.Key, k)
+		if err != nil {
+			return nil, err
+		}
+		keyString, ok := key.(string)
+		if !ok {
+			keyText, err := json.Marshal(key)
+			if err != nil {
+				return nil, err
+			}
+			keyString = string(keyText)
+		}
+		if result[keyString], err = toJSON(*desc.Value, v); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
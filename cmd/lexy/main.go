@@ -0,0 +1,303 @@
+/*
+Command lexy encodes and decodes keys using a Codec described by a schema, as accepted by [lexy.ParseSchema].
+
+Usage:
+
+	lexy decode|encode|range -schema SCHEMA | -schema-file FILE [-base64] [ARG...]
+
+Commands:
+
+	decode  decodes each key, in hex or base64, and prints its value as JSON
+	encode  encodes each JSON value, and prints its key in hex or base64
+	range   encodes each JSON value, and prints the range of keys having that key as a prefix,
+	        as a JSON object {"begin": KEY, "end": KEY}, where end is null if the range is unbounded
+
+Each ARG is a key or a JSON value. If there are no ARGs, they are read from standard input, one per line,
+skipping blank lines. ARGs beginning with "-", like negative numbers, must follow "--".
+Output is written one line per ARG. If an ARG cannot be processed, an error is written to standard error,
+processing continues with the next ARG, and lexy exits with status 1.
+When a key cannot be decoded, the error includes the offset at which decoding failed,
+and as much of the key as could be decoded before it.
+
+A schema file may contain comments starting with "#" and extending to the end of the line,
+and the schema may span multiple lines.
+
+	# Keys for the events table.
+	tuple(
+	    uint32,              # tenant ID
+	    desc(time),          # newest first
+	    terminated(string),  # event name
+	    slice(int64)
+	)
+
+Values are written as JSON as follows, and JSON values are read the same way.
+Integers and floating point numbers are JSON numbers, except that NaN and infinite floats are the strings
+"NaN", "+Inf", and "-Inf". Complex numbers are strings like "(1+2i)".
+Byte slices are base64 strings. Big numbers, versions, civil dates and times,
+and times are strings, and times are written in RFC 3339 format.
+Durations are numbers of nanoseconds. Slices and tuples are arrays, and maps are objects,
+with keys which are not strings written as the JSON text of the key.
+Nils and absent optional values are null.
+
+To find the range of keys beginning with the first elements of a tuple,
+use the range command with a schema for those elements. For example, with the schema above:
+
+	lexy range -schema 'tuple(uint32, desc(time))' '[7, "2024-01-02T03:04:05Z"]'
+*/
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/phiryll/lexy"
+)
+
+var (
+	errUsage    = errors.New("usage: lexy decode|encode|range -schema SCHEMA | -schema-file FILE [-base64] [ARG...]")
+	errTrailing = errors.New("trailing data")
+	errDecode   = errors.New("truncated or malformed key")
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// command is the state of a lexy invocation.
+type command struct {
+	codec  lexy.Codec[any]
+	desc   lexy.Description
+	base64 bool
+	out    io.Writer
+}
+
+// run runs lexy with args, not including the program name, returning the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, errUsage)
+		return 2 //nolint:mnd
+	}
+	name, args := args[0], args[1:]
+	flags := flag.NewFlagSet("lexy "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	schema := flags.String("schema", "", "the schema of the keys")
+	schemaFile := flags.String("schema-file", "", "a file containing the schema of the keys")
+	useBase64 := flags.Bool("base64", false, "read and write keys in base64 instead of hex")
+	if err := flags.Parse(args); err != nil {
+		return 2 //nolint:mnd
+	}
+	process := map[string]func(*command, string) error{
+		"decode": (*command).decode,
+		"encode": (*command).encode,
+		"range":  (*command).keyRange,
+	}[name]
+	if process == nil || (*schema == "") == (*schemaFile == "") {
+		fmt.Fprintln(stderr, errUsage)
+		return 2 //nolint:mnd
+	}
+	cmd, err := newCommand(*schema, *schemaFile, *useBase64, stdout)
+	if err != nil {
+		fmt.Fprintln(stderr, "lexy:", err)
+		return 2 //nolint:mnd
+	}
+	status := 0
+	err = forEachArg(flags.Args(), stdin, func(arg string) {
+		if err := processArg(process, cmd, arg); err != nil {
+			fmt.Fprintf(stderr, "lexy: %s: %v\n", arg, err)
+			status = 1
+		}
+	})
+	if err != nil {
+		fmt.Fprintln(stderr, "lexy:", err)
+		return 1
+	}
+	return status
+}
+
+// processArg calls process with cmd and arg, returning an error instead if it panics,
+// so that an ARG lexy does not handle correctly is reported like any other invalid ARG.
+//
+//nolint:nonamedreturns
+func processArg(process func(*command, string) error, cmd *command, arg string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r) //nolint:err113
+		}
+	}()
+	return process(cmd, arg)
+}
+
+// newCommand returns a command using the Codec for schema, or for the schema in schemaFile if schema is empty.
+//
+// The Codec is normalized by parsing its Description, which produces the same encodings,
+// but with Go types determined by the Kinds of the Description.
+// For example, both int and duration are normalized to int64.
+func newCommand(schema, schemaFile string, useBase64 bool, out io.Writer) (*command, error) {
+	if schemaFile != "" {
+		data, err := os.ReadFile(schemaFile)
+		if err != nil {
+			return nil, err
+		}
+		schema = stripComments(string(data))
+	}
+	codec, err := lexy.ParseSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	desc := lexy.Describe(codec)
+	if codec, err = lexy.ParseSchema(desc.String()); err != nil {
+		return nil, err
+	}
+	return &command{codec, desc, useBase64, out}, nil
+}
+
+func stripComments(schema string) string {
+	lines := strings.Split(schema, "\n")
+	for i, line := range lines {
+		lines[i], _, _ = strings.Cut(line, "#")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// forEachArg calls f with each of args, or each non-blank line of stdin if args is empty.
+func forEachArg(args []string, stdin io.Reader, f func(string)) error {
+	if len(args) > 0 {
+		for _, arg := range args {
+			f(arg)
+		}
+		return nil
+	}
+	scanner := bufio.NewScanner(stdin)
+	scanner.Buffer(nil, 1<<24) //nolint:mnd
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			f(line)
+		}
+	}
+	return scanner.Err()
+}
+
+// unmarshal decodes the JSON in data into value, decoding numbers as json.Numbers.
+func unmarshal(data []byte, value *any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errTrailing
+	}
+	return nil
+}
+
+func (c *command) parseKey(s string) ([]byte, error) {
+	if c.base64 {
+		return base64.StdEncoding.DecodeString(s)
+	}
+	return hex.DecodeString(strings.TrimPrefix(strings.ToLower(s), "0x"))
+}
+
+func (c *command) formatKey(key []byte) string {
+	if c.base64 {
+		return base64.StdEncoding.EncodeToString(key)
+	}
+	return hex.EncodeToString(key)
+}
+
+// encodeArg encodes the JSON value in arg.
+// The Codec panics if the value is invalid, like an out of range number, which processArg reports as an error.
+func (c *command) encodeArg(arg string) ([]byte, error) {
+	var jsonValue any
+	if err := unmarshal([]byte(arg), &jsonValue); err != nil {
+		return nil, err
+	}
+	value, err := fromJSON(c.desc, jsonValue)
+	if err != nil {
+		return nil, err
+	}
+	return c.codec.Append([]byte{}, value), nil
+}
+
+// println writes the JSON encoding of value as a line.
+func (c *command) println(value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.out, "%s\n", data)
+	return err
+}
+
+func (c *command) decode(arg string) error {
+	key, err := c.parseKey(arg)
+	if err != nil {
+		return err
+	}
+	value, err := c.get(key)
+	if err != nil {
+		return err
+	}
+	jsonValue, err := toJSON(c.desc, value)
+	if err != nil {
+		return err
+	}
+	return c.println(jsonValue)
+}
+
+// get decodes key, returning an error if it cannot be decoded or has trailing bytes.
+//
+//nolint:nonamedreturns
+func (c *command) get(key []byte) (value any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = c.decodeError(key)
+		}
+	}()
+	value, rest := c.codec.Get(key)
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%w: %d bytes after %s", errTrailing, len(rest), lexy.Format(c.codec, key))
+	}
+	return value, nil
+}
+
+// decodeError returns the error for key, which cannot be decoded.
+// The error includes the offset at which decoding failed, and what lexy.FormatPartial decoded before it.
+func (c *command) decodeError(key []byte) error {
+	formatted, err := lexy.FormatPartial(c.codec, key)
+	var decodeErr *lexy.DecodeError
+	if !errors.As(err, &decodeErr) {
+		return errDecode
+	}
+	return fmt.Errorf("%w at offset %d: %s", errDecode, decodeErr.Offset, formatted)
+}
+
+func (c *command) encode(arg string) error {
+	key, err := c.encodeArg(arg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(c.out, c.formatKey(key))
+	return err
+}
+
+func (c *command) keyRange(arg string) error {
+	key, err := c.encodeArg(arg)
+	if err != nil {
+		return err
+	}
+	r := lexy.KeyRange{}.WithPrefix(key)
+	var end any
+	if r.End != nil {
+		end = c.formatKey(r.End)
+	}
+	return c.println(map[string]any{"begin": c.formatKey(r.Begin), "end": end})
+}
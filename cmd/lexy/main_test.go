@@ -0,0 +1,192 @@
+package main_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	lexy "github.com/phiryll/lexy/cmd/lexy"
+)
+
+// runLexy runs the lexy command with args and stdin, returning its exit status, stdout, and stderr.
+func runLexy(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	status := lexy.Run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+const (
+	eventSchema = "tuple(uint32, desc(time), terminated(string), slice(int64))"
+	eventJSON   = `[7,"2024-01-02T03:04:05Z","ev",[1,-2]]`
+	eventKey    = "000000077fffffff9a6c82daffffffff7fffffff6576000380000000000000017ffffffffffffffe"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+	status, stdout, stderr := runLexy("", "encode", "-schema", eventSchema, eventJSON)
+	assert.Equal(t, 0, status)
+	assert.Equal(t, eventKey+"\n", stdout)
+	assert.Empty(t, stderr)
+
+	status, stdout, stderr = runLexy("", "decode", "-schema", eventSchema, eventKey)
+	assert.Equal(t, 0, status)
+	assert.Equal(t, eventJSON+"\n", stdout)
+	assert.Empty(t, stderr)
+}
+
+func TestSchemaFile(t *testing.T) {
+	t.Parallel()
+	status, stdout, stderr := runLexy(eventKey+"\n\n"+eventKey+"\n", "decode", "-schema-file", "testdata/events.schema")
+	assert.Equal(t, 0, status)
+	assert.Equal(t, eventJSON+"\n"+eventJSON+"\n", stdout)
+	assert.Empty(t, stderr)
+}
+
+func TestRange(t *testing.T) {
+	t.Parallel()
+	status, stdout, stderr := runLexy("", "range", "-schema", "tuple(uint32, desc(time))", `[7, "2024-01-02T03:04:05Z"]`)
+	assert.Equal(t, 0, status)
+	assert.JSONEq(t,
+		`{"begin":"000000077fffffff9a6c82daffffffff7fffffff","end":"000000077fffffff9a6c82daffffffff80"}`, stdout)
+	assert.Empty(t, stderr)
+
+	status, stdout, _ = runLexy("", "range", "-schema", "uint8", "255")
+	assert.Equal(t, 0, status)
+	assert.JSONEq(t, `{"begin":"ff","end":null}`, stdout)
+
+	// Negative numbers must follow "--" to not be parsed as flags.
+	status, stdout, _ = runLexy("", "range", "-schema", "int8", "--", "-1")
+	assert.Equal(t, 0, status)
+	assert.JSONEq(t, `{"begin":"7f","end":"80"}`, stdout)
+}
+
+func TestBase64(t *testing.T) {
+	t.Parallel()
+	status, stdout, _ := runLexy("", "encode", "-base64", "-schema", "string", `"hi"`)
+	assert.Equal(t, 0, status)
+	assert.Equal(t, "aGk=\n", stdout)
+	status, stdout, _ = runLexy("aGk=\n", "decode", "-base64", "-schema", "string")
+	assert.Equal(t, 0, status)
+	assert.Equal(t, `"hi"`+"\n", stdout)
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		schema string
+		json   string
+	}{
+		{"bool", "true"},
+		{"uint", "18446744073709551615"},
+		{"int", "-9223372036854775808"},
+		{"duration", "-1000000000"},
+		{"tuple(int8, uint16, int32, uint64)", "[-1,2,-3,4]"},
+		{"float32", "1.5"},
+		{"desc(float64)", `"-Inf"`},
+		{"complex128", `"(1+2i)"`},
+		{"natural_string", `"file10"`},
+		{"folded_string", `"Hello"`},
+		{"shortlex_string", `"abc"`},
+		{"domain_name", `"www.example.com"`},
+		{"bytes", `"AAEC"`},
+		{"shortlex_bytes", "null"},
+		{"bit_set", "[true,false,true]"},
+		{"time_utc", `"2024-01-02T03:04:05.123456789Z"`},
+		{"time_seconds", `"2024-01-02T03:04:05Z"`},
+		{"time_millis", `"2024-01-02T03:04:05.123Z"`},
+		{"time_micros", `"2024-01-02T03:04:05.123456Z"`},
+		{"time", `"2024-01-02T03:04:05+05:00"`},
+		{"date", `"2024-02-29"`},
+		{"time_of_day", `"23:59:59.5"`},
+		{"date_time", `"2024-02-29T23:59:59.5"`},
+		{"semver", `"1.2.3-rc.1+build"`},
+		{"big_int", `"-123456789012345678901234567890"`},
+		{"big_float", `"1.5e+100"`},
+		{"big_float", `"1.000000000000000000000000001"`},
+		{"big_float", `"-3.14159265358979323846264338327950288e-100"`},
+		{"big_rat", `"-1/3"`},
+		{"nils_last(big_int)", "null"},
+		{"slice(terminated(string))", `["a","b"]`},
+		{"nils_last(slice(string))", "null"},
+		{"shortlex_slice(uint8)", "[1,2]"},
+		{"map(string, bool)", `{"a":true,"b":false}`},
+		{"map(int32, optional(string))", `{"-1":null,"2":"x"}`},
+		{"optional(tuple(uint8, string))", `[1,"a"]`},
+		{"optional(int8)", "null"},
+	} {
+		t.Run(tt.schema, func(t *testing.T) {
+			t.Parallel()
+			status, key, stderr := runLexy(tt.json+"\n", "encode", "-schema", tt.schema)
+			assert.Equal(t, 0, status)
+			assert.Empty(t, stderr)
+			status, stdout, stderr := runLexy(key, "decode", "-schema", tt.schema)
+			assert.Equal(t, 0, status)
+			assert.Empty(t, stderr)
+			assert.Equal(t, tt.json+"\n", stdout)
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name   string
+		args   []string
+		status int
+		stdout string
+		stderr string
+	}{
+		{"no command", nil, 2, "", "usage: lexy"},
+		{"unknown command", []string{"frob", "-schema", "int8"}, 2, "", "usage: lexy"},
+		{"no schema", []string{"decode", "00"}, 2, "", "usage: lexy"},
+		{"both schemas", []string{"decode", "-schema", "int8", "-schema-file", "x", "00"}, 2, "", "usage: lexy"},
+		{"bad flag", []string{"decode", "-frob"}, 2, "", "flag provided but not defined: -frob"},
+		{"bad schema", []string{"decode", "-schema", "int9", "00"}, 2, "", `lexy: invalid schema "int9"`},
+		{"missing schema file", []string{"decode", "-schema-file", "testdata/missing", "00"}, 2, "", "lexy: open"},
+		{"bad hex", []string{"decode", "-schema", "int8", "0g", "81"}, 1, "1\n", "lexy: 0g: encoding/hex"},
+		{"bad JSON", []string{"encode", "-schema", "int8", "{", "1"}, 1, "81\n", "lexy: {: unexpected EOF"},
+		{"trailing JSON", []string{"encode", "-schema", "int8", "1 2"}, 1, "", "lexy: 1 2: trailing data"},
+		{"wrong JSON type", []string{"encode", "-schema", "int8", `"1"`}, 1, "", "wrong JSON type for int8: string"},
+		{"out of range", []string{"encode", "-schema", "int8", "128"}, 1, "", "value out of range"},
+		{"tuple length", []string{"encode", "-schema", "tuple(int8, int8)", "[1]"}, 1, "", "must have length 2"},
+		{"trailing data", []string{"decode", "-schema", "int8", "8182"}, 1, "", "lexy: 8182: trailing data: 1 bytes after 1"},
+		{
+			"undecodable",
+			[]string{"decode", "-schema", eventSchema, "000000077fff"},
+			1,
+			"",
+			"lexy: 000000077fff: truncated or malformed key at offset 4: (7, ...)\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			status, stdout, stderr := runLexy("", tt.args...)
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.stdout, stdout)
+			assert.Contains(t, stderr, tt.stderr)
+		})
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	t.Parallel()
+	truncated := eventKey[:len(eventKey)-2]
+	status, stdout, stderr := runLexy("", "decode", "-schema-file", "testdata/events.schema", truncated)
+	assert.Equal(t, 1, status)
+	assert.Empty(t, stdout)
+	want := `: truncated or malformed key at offset 32: (7, 2024-01-02T03:04:05Z, "ev", [1, ...])`
+	assert.Equal(t, "lexy: "+truncated+want+"\n", stderr)
+
+	truncated = "000000077fffffff9a6c82daffffff"
+	status, _, stderr = runLexy("", "decode", "-schema-file", "testdata/events.schema", truncated)
+	assert.Equal(t, 1, status)
+	assert.Equal(t, "lexy: "+truncated+": truncated or malformed key at offset 4: (7, ...)\n", stderr)
+}
+
+func TestProcessArgPanics(t *testing.T) {
+	t.Parallel()
+	assert.EqualError(t, lexy.ProcessArg(func() error { panic("unexpected") }), "unexpected")
+	assert.NoError(t, lexy.ProcessArg(func() error { return nil }))
+}
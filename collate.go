@@ -0,0 +1,72 @@
+package lexy
+
+import "fmt"
+
+// A Collator produces collation keys for strings, and is used by [CollatedString].
+// The encoded order of strings is the lexicographical order of their collation keys.
+//
+// Implementations must be deterministic, and should be safe for concurrent use.
+// A Collator from another package can be adapted with a small wrapper type.
+// For example, a golang.org/x/text/collate.Collator could be adapted like this:
+//
+//	type keyCollator struct {
+//	    c *collate.Collator
+//	}
+//
+//	func (k keyCollator) AppendKey(buf []byte, s string) []byte {
+//	    var b collate.Buffer
+//	    return append(buf, k.c.KeyFromString(&b, s)...)
+//	}
+//
+// That type is not safe for concurrent use, because collate.Collator is not.
+type Collator interface {
+	// AppendKey appends the collation key for s to buf, returning the updated buffer.
+	AppendKey(buf []byte, s string) []byte
+}
+
+// collatedCodec is the Codec for strings in the order defined by a Collator, retaining the original string.
+//
+// A string is encoded as its collation key, escaped and terminated,
+// followed by the original string's bytes as-is.
+// The collation key is the primary sort key, and the original string is the tiebreaker,
+// so strings with the same collation key are adjacent, and ordered as they are by stringCodec.
+// Get will fully consume its argument buffer, and never invokes the Collator.
+type collatedCodec struct {
+	collator Collator
+}
+
+func (c collatedCodec) Append(buf []byte, value string) []byte {
+	start := len(buf)
+	buf = c.collator.AppendKey(buf, value)
+	n := termNumAdded(buf[start:])
+	buf = append(buf, make([]byte, n)...)
+	term(buf[start:], n)
+	return append(buf, value...)
+}
+
+func (c collatedCodec) Put(buf []byte, value string) []byte {
+	// Limiting the capacity keeps the Collator from writing past the end of buf.
+	// The key is appended in place if it fits, otherwise the check below will panic.
+	key := c.collator.AppendKey(buf[:0:len(buf)], value)
+	n := termNumAdded(key)
+	_ = buf[len(key)+n+len(value)-1] // check that we have room
+	copy(buf, key)
+	term(buf[:len(key)+n], n)
+	return copyAll(buf[len(key)+n:], []byte(value))
+}
+
+func (collatedCodec) Get(buf []byte) (string, []byte) {
+	_, buf = termGet(buf)
+	return string(buf), buf[len(buf):]
+}
+
+func (collatedCodec) RequiresTerminator() bool {
+	return true
+}
+
+func (c collatedCodec) Describe() Description {
+	if _, ok := c.collator.(caseFolder); ok {
+		return Description{Kind: "folded_string"}
+	}
+	return Description{Kind: "collated_string", Params: []any{fmt.Sprintf("%T", c.collator)}}
+}
@@ -0,0 +1,109 @@
+package lexy_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/phiryll/lexy"
+)
+
+// A stand-in for a real collator.
+// Case and a few accents are ignored, and so is everything other than letters and digits.
+type testCollator struct{}
+
+var testAccents = strings.NewReplacer(
+	"à", "a", "á", "a", "â", "a", "ä", "a",
+	"è", "e", "é", "e", "ê", "e", "ë", "e",
+	"ï", "i", "ö", "o", "ü", "u", "ç", "c", "ñ", "n",
+)
+
+func (testCollator) AppendKey(buf []byte, s string) []byte {
+	for _, r := range testAccents.Replace(strings.ToLower(s)) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') {
+			buf = append(buf, byte(r))
+		}
+	}
+	return buf
+}
+
+// Panics if asked for a key, to verify Get doesn't use the Collator.
+type panickingCollator struct{}
+
+func (panickingCollator) AppendKey([]byte, string) []byte {
+	panic("should not be called")
+}
+
+func TestCollatedString(t *testing.T) {
+	t.Parallel()
+	codec := lexy.CollatedString(testCollator{})
+	assert.True(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[string]{
+		{"empty", "", []byte{term}},
+		{"punctuation", "-!", []byte{term, '-', '!'}},
+		{"Café", "Café", []byte{'c', 'a', 'f', 'e', term, 'C', 'a', 'f', 0xC3, 0xA9}},
+	})
+	assert.PanicsWithError(t, "nil collator", func() {
+		lexy.CollatedString(nil)
+	})
+
+	getOnly := lexy.CollatedString(panickingCollator{})
+	got, _ := getOnly.Get(codec.Append(nil, "Crème brûlée"))
+	assert.Equal(t, "Crème brûlée", got)
+}
+
+func TestCollatedStringOrdering(t *testing.T) {
+	t.Parallel()
+	testCases := []testCase[string]{
+		{"empty", "", nil},
+		{"!", "!", nil},
+		{"Café", "Café", nil},
+		{"cafe", "cafe", nil},
+		{"café", "café", nil},
+		{"Cafe au lait", "Cafe au lait", nil},
+		{"café-au-lait", "café-au-lait", nil},
+		{"Cafeteria", "Cafeteria", nil},
+		{"naïve", "naïve", nil},
+		{"Nation", "Nation", nil},
+		{"Zoë", "Zoë", nil},
+		{"zoo", "zoo", nil},
+	}
+	codec := lexy.CollatedString(testCollator{})
+	testOrdering(t, codec, testCases)
+	testOrdering(t, lexy.Terminate(codec), testCases)
+	slices.Reverse(testCases)
+	testOrdering(t, lexy.Negate(codec), testCases)
+
+	sliceCodec := lexy.SliceOf(codec)
+	testCodec(t, sliceCodec, fillTestData(sliceCodec, []testCase[[]string]{
+		{"slice", []string{"Zoë", "", "\x00", "Café"}, nil},
+	}))
+	testOrdering(t, sliceCodec, []testCase[[]string]{
+		{"[cafe]", []string{"cafe"}, nil},
+		{"[café, a]", []string{"café", "a"}, nil},
+		{"[zoo]", []string{"zoo"}, nil},
+	})
+}
+
+func TestCollatedStringPutShortBuf(t *testing.T) {
+	t.Parallel()
+	codec := lexy.CollatedString(testCollator{})
+	buf := make([]byte, 10)
+	assert.Panics(t, func() {
+		codec.Put(buf[:3], "abcdef")
+	})
+	// The Collator must not write past the end of the buffer passed to Put.
+	assert.Equal(t, make([]byte, 7), buf[3:])
+}
+
+//nolint:paralleltest // allocation counts are unreliable when run in parallel
+func TestCollatedStringPutNoAlloc(t *testing.T) {
+	codec := lexy.FoldedString()
+	buf := make([]byte, 256)
+	allocs := testing.AllocsPerRun(100, func() {
+		codec.Put(buf, "Crème Brûlée, with a name long enough to escape a small stack buffer")
+	})
+	assert.Zero(t, allocs)
+}
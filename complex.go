@@ -32,6 +32,10 @@ func (complex64Codec) RequiresTerminator() bool {
 	return false
 }
 
+func (complex64Codec) Describe() Description {
+	return Description{Kind: "complex64"}
+}
+
 func (complex128Codec) Append(buf []byte, value complex128) []byte {
 	//nolint:mnd
 	buf = stdFloat64.Append(slices.Grow(buf, 16), real(value))
@@ -52,3 +56,7 @@ func (complex128Codec) Get(buf []byte) (complex128, []byte) {
 func (complex128Codec) RequiresTerminator() bool {
 	return false
 }
+
+func (complex128Codec) Describe() Description {
+	return Description{Kind: "complex128"}
+}
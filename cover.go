@@ -0,0 +1,137 @@
+package lexy
+
+import "container/heap"
+
+// maxSplitsPerRange limits the number of cells, in case splitting many cells does not increase the number of ranges.
+const maxSplitsPerRange = 64
+
+// A coverer defines the cells of a space-filling curve for coverCells, relative to a query.
+// The encodings of the points in a cell are contiguous,
+// and the encodings of its sub-cells are in order and within the encodings of the cell.
+type coverer[C any] interface {
+	// waste returns an estimate of the number of points in cell which are not in the query.
+	waste(cell C) float64
+
+	// splittable returns true if splitting cell could reduce its waste.
+	splittable(cell C) bool
+
+	// split returns the sub-cells of cell which intersect the query, in order.
+	split(cell C) []C
+
+	// adjacent returns true if the encodings of b immediately follow the encodings of a.
+	adjacent(a, b C) bool
+}
+
+// A coverNode is a cell in the ordered list of cells being built by coverCells.
+type coverNode[C any] struct {
+	cell       C
+	waste      float64
+	prev, next *coverNode[C]
+}
+
+// coverHeap is a max-heap of the splittable coverNodes, by waste.
+type coverHeap[C any] []*coverNode[C]
+
+func (h coverHeap[C]) Len() int {
+	return len(h)
+}
+
+func (h coverHeap[C]) Less(i, j int) bool {
+	return h[i].waste > h[j].waste
+}
+
+func (h coverHeap[C]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *coverHeap[C]) Push(x any) {
+	*h = append(*h, x.(*coverNode[C]))
+}
+
+func (h *coverHeap[C]) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return x
+}
+
+// countRanges returns the number of ranges covering the encodings of cells, which must be in order,
+// after merging adjacent cells.
+func countRanges[C any](cv coverer[C], cells []C) int {
+	n := 0
+	for i, cell := range cells {
+		if i == 0 || !cv.adjacent(cells[i-1], cell) {
+			n++
+		}
+	}
+	return n
+}
+
+// coverCells returns cells, in order, whose encodings cover those of every point in root within the query,
+// and which form at most maxRanges ranges after merging adjacent cells.
+//
+// This starts with root, and repeatedly replaces the cell with the most waste by its sub-cells,
+// unless that would result in more than maxRanges ranges, in which case that cell is not split further.
+// This stops when no cell can be split, or when there are maxRanges*maxSplitsPerRange cells.
+// Cells are kept in a heap ordered by waste, and the number of ranges is updated incrementally,
+// so each split takes time logarithmic in the number of cells.
+func coverCells[C any](cv coverer[C], root C, maxRanges int) []C {
+	maxRanges = max(1, maxRanges)
+	head := &coverNode[C]{cell: root, waste: cv.waste(root)}
+	var pending coverHeap[C]
+	if cv.splittable(root) {
+		pending = append(pending, head)
+	}
+	numCells, numRanges := 1, 1
+	var before, after []C // reused across splits
+	for len(pending) > 0 && numCells < maxRanges*maxSplitsPerRange {
+		node := heap.Pop(&pending).(*coverNode[C])
+		children := cv.split(node.cell)
+		// Only the adjacencies of the split cell and its neighbors can change.
+		before, after = before[:0], after[:0]
+		if node.prev != nil {
+			before = append(before, node.prev.cell)
+			after = append(after, node.prev.cell)
+		}
+		before = append(before, node.cell)
+		after = append(after, children...)
+		if node.next != nil {
+			before = append(before, node.next.cell)
+			after = append(after, node.next.cell)
+		}
+		added := countRanges(cv, after) - countRanges(cv, before)
+		if numRanges+added > maxRanges {
+			continue
+		}
+		numRanges += added
+		numCells += len(children) - 1
+		prev := node.prev
+		for _, child := range children {
+			childNode := &coverNode[C]{cell: child, waste: cv.waste(child), prev: prev}
+			if prev == nil {
+				head = childNode
+			} else {
+				prev.next = childNode
+			}
+			prev = childNode
+			if cv.splittable(child) {
+				heap.Push(&pending, childNode)
+			}
+		}
+		if prev == nil {
+			head = node.next
+		} else {
+			prev.next = node.next
+		}
+		if node.next != nil {
+			node.next.prev = prev
+		}
+	}
+	var cells []C
+	for node := head; node != nil; node = node.next {
+		cells = append(cells, node.cell)
+	}
+	return cells
+}
@@ -0,0 +1,117 @@
+package lexy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Describer is implemented by Codecs which can describe their encodings.
+// All Codecs provided by lexy implement Describer.
+// Use [Describe] to describe any Codec, whether or not it implements Describer.
+type Describer interface {
+	// Describe returns a description of this Codec's encoding.
+	Describe() Description
+}
+
+// Description is a structured description of a Codec's encoding, returned by [Describe].
+// Two Codecs with equal Descriptions, as compared by [reflect.DeepEqual], produce the same encodings,
+// except when they contain the Kinds "collated_string", "custom", or "one_of".
+// The first two are identified only by the Go type of their Collator or Codec,
+// so for example two Collators of the same type for different locales have equal Descriptions.
+// A "one_of" does not include the Matches functions which choose the variant encoding a value.
+// This makes Descriptions useful for printing and documenting key layouts,
+// and for detecting accidental changes to them in tests.
+//
+// Kind describes the encoding, not the Go type, so for example [Uint] and [Uint64] both have Kind "uint64",
+// and [Duration] has Kind "int64".
+// Where there is one, Kind is the name used by [ParseSchema], like "bool", "string", "time", "slice", or "map".
+// These are the other Kinds, and their Params if any:
+//
+//	empty                   Empty
+//	fixed_bit_set           FixedBitSet         the size
+//	collated_string         CollatedString      the Go type of the Collator
+//	path_segments           PathSegments        the separator
+//	pointer                 PointerTo
+//	enum                    Enum                the values, in order
+//	one_of                  OneOf               the tags, in the same order as Elems
+//	zorder2, zorder3        ZOrder2, ZOrder3
+//	hilbert2d               Hilbert2D           the bits per coordinate
+//	latlng                  LatLng              the precision in bits
+//	custom                  a Codec which does not implement Describer, the Go type of the Codec
+//
+// The encoding described is that of Kind, escaped and terminated if Terminated,
+// and then with all bits flipped if Negated.
+// The Codecs used by [SliceOf] and similar functions to encode elements are described as they are used,
+// so for example SliceOf(String()) has an Elem which is Terminated.
+type Description struct {
+	Kind   string
+	Params []any
+
+	// Elem describes the Codec for elements, referents, coordinates, or an Enum's fallback, if any.
+	Elem *Description
+
+	// Key and Value describe the Codecs for a map's keys and values.
+	Key   *Description
+	Value *Description
+
+	// Elems describe the Codecs for the variants of a OneOf, or the elements of a tuple.
+	Elems []Description
+
+	NilsLast   bool
+	Negated    bool
+	Terminated bool
+}
+
+// Describe returns the description of codec.
+// If codec does not implement [Describer], this returns a Description with Kind "custom" and codec's Go type.
+func Describe[T any](codec Codec[T]) Description {
+	if d, ok := codec.(Describer); ok {
+		return d.Describe()
+	}
+	return Description{Kind: "custom", Params: []any{fmt.Sprintf("%T", codec)}}
+}
+
+func describeRef[T any](codec Codec[T]) *Description {
+	d := Describe(codec)
+	return &d
+}
+
+func isNilsLast(prefix Prefix) bool {
+	return prefix == PrefixNilsLast
+}
+
+// String returns d in the syntax of [ParseSchema], with Params written as additional leading arguments.
+// NilsLast, Terminated, and Negated are written as nils_last(...), terminated(...), and desc(...), in that order.
+// For example, Negate(SliceOf(String())) is described as "desc(slice(terminated(string)))".
+func (d Description) String() string {
+	var args []string
+	for _, param := range d.Params {
+		if s, ok := param.(string); ok {
+			args = append(args, fmt.Sprintf("%q", s))
+		} else {
+			args = append(args, fmt.Sprintf("%v", param))
+		}
+	}
+	for _, elem := range []*Description{d.Elem, d.Key, d.Value} {
+		if elem != nil {
+			args = append(args, elem.String())
+		}
+	}
+	for _, elem := range d.Elems {
+		args = append(args, elem.String())
+	}
+	s := d.Kind
+	if len(args) > 0 {
+		s += "(" + strings.Join(args, ", ") + ")"
+	}
+	if d.NilsLast {
+		s = "nils_last(" + s + ")"
+	}
+	if d.Terminated {
+		s = "terminated(" + s + ")"
+	}
+	if d.Negated {
+		s = "desc(" + s + ")"
+	}
+	return s
+}
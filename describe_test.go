@@ -0,0 +1,166 @@
+package lexy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+type myInt int32
+
+type myStringer interface {
+	String() string
+}
+
+func TestDescribeString(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name  string
+		codec any
+		want  string
+	}{
+		{"bool", lexy.Bool(), "bool"},
+		{"uint", lexy.Uint(), "uint64"},
+		{"uint8", lexy.Uint8(), "uint8"},
+		{"int", lexy.Int(), "int64"},
+		{"int16", lexy.Int16(), "int16"},
+		{"cast int32", lexy.CastInt32[myInt](), "int32"},
+		{"float32", lexy.Float32(), "float32"},
+		{"complex128", lexy.Complex128(), "complex128"},
+		{"string", lexy.String(), "string"},
+		{"terminated string", lexy.TerminatedString(), "terminated(string)"},
+		{"natural", lexy.NaturalString(), "terminated(natural_string)"},
+		{"folded", lexy.FoldedString(), "folded_string"},
+		{"collated", lexy.CollatedString(testCollator{}), `collated_string("lexy_test.testCollator")`},
+		{"domain", lexy.DomainName(), "domain_name"},
+		{"path", lexy.PathSegments("/"), `path_segments("/")`},
+		{"time", lexy.Time(), "time"},
+		{"time utc", lexy.TimeUTC(), "time_utc"},
+		{"time seconds", lexy.TimeSeconds(), "time_seconds"},
+		{"time millis", lexy.TimeMillis(), "time_millis"},
+		{"time micros", lexy.TimeMicros(), "time_micros"},
+		{"time zoned", lexy.TimeZoned(), "time_zoned"},
+		{"time desc", lexy.TimeDesc(), "desc(time)"},
+		{"time seconds desc", lexy.TimeSecondsDesc(), "desc(time_seconds)"},
+		{"duration", lexy.Duration(), "int64"},
+		{"duration desc", lexy.DurationDesc(), "desc(int64)"},
+		{"date", lexy.CivilDate(), "date"},
+		{"time of day", lexy.CivilTimeOfDay(), "time_of_day"},
+		{"date time", lexy.CivilDateTime(), "date_time"},
+		{"semver", lexy.SemVer(), "semver"},
+		{"big int", lexy.BigInt(), "big_int"},
+		{"big float", lexy.NilsLast(lexy.BigFloat()), "nils_last(big_float)"},
+		{"big rat", lexy.BigRat(), "big_rat"},
+		{"bytes", lexy.Bytes(), "bytes"},
+		{"terminated bytes", lexy.TerminatedBytes(), "terminated(bytes)"},
+		{"cast bytes", lexy.NilsLast(lexy.CastBytes[[]byte]()), "nils_last(bytes)"},
+		{"bit set", lexy.BitSet(), "bit_set"},
+		{"fixed bit set", lexy.FixedBitSet(12), "fixed_bit_set(12)"},
+		{"empty", lexy.Empty[struct{}](), "empty"},
+		{"pointer", lexy.PointerTo(lexy.String()), "pointer(string)"},
+		{"optional", lexy.NilsLast(lexy.OptionalOf(lexy.Int8())), "nils_last(optional(int8))"},
+		{"slice", lexy.SliceOf(lexy.String()), "slice(terminated(string))"},
+		{"slice of int", lexy.SliceOf(lexy.Int32()), "slice(int32)"},
+		{"cast slice", lexy.CastSliceOf[[]myInt](lexy.CastInt32[myInt]()), "slice(int32)"},
+		{"map", lexy.MapOf(lexy.String(), lexy.PointerTo(lexy.Uint16())), "map(terminated(string), pointer(uint16))"},
+		{"shortlex string", lexy.ShortLexString(), "shortlex_string"},
+		{"shortlex bytes", lexy.ShortLexBytes(), "shortlex_bytes"},
+		{"shortlex slice", lexy.ShortLexSliceOf(lexy.Bytes()), "shortlex_slice(terminated(bytes))"},
+		{"enum", lexy.Enum("b", "a"), `enum("b", "a")`},
+		{"enum fallback", lexy.EnumWithFallback(lexy.Int8(), 3, 1), "enum(3, 1, int8)"},
+		{"zorder2", lexy.ZOrder2(lexy.Uint16()), "zorder2(uint16)"},
+		{"zorder3", lexy.ZOrder3(lexy.Int8()), "zorder3(int8)"},
+		{"hilbert", lexy.Hilbert2D(lexy.Uint32(), 20), "hilbert2d(20, uint32)"},
+		{"latlng", lexy.LatLng(25), "latlng(25)"},
+		{"negate", lexy.Negate(lexy.Int32()), "desc(int32)"},
+		{"negate escaped", lexy.Negate(lexy.String()), "desc(terminated(string))"},
+		{"negate terminated", lexy.Negate(lexy.TerminatedString()), "desc(terminated(string))"},
+		{"negate nils last", lexy.Negate(lexy.NilsLast(lexy.Bytes())), "desc(terminated(nils_last(bytes)))"},
+		{"negate negate", lexy.Negate(lexy.Negate(lexy.Int32())), "int32"},
+		{"negate negate escaped", lexy.Negate(lexy.Negate(lexy.String())), "terminated(string)"},
+		{"custom", lexy.SliceOf[Quaternion](quatCodec), "slice(custom(\"lexy_test.quaternionCodec\"))"},
+		{
+			"one of",
+			lexy.OneOf(
+				lexy.VariantOf[myStringer](1, lexy.SemVer()),
+				lexy.VariantOf[myStringer](2, lexy.PointerTo(lexy.BigInt())),
+			),
+			"one_of(1, 2, semver, pointer(big_int))",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			describer, ok := tt.codec.(lexy.Describer)
+			require.True(t, ok, "%T does not implement Describer", tt.codec)
+			assert.Equal(t, tt.want, describer.Describe().String())
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+	codec := lexy.NilsLast(lexy.MapOf(lexy.Int8(), lexy.Negate(lexy.SliceOf(lexy.Time()))))
+	assert.Equal(t, lexy.Description{
+		Kind: "map",
+		Key:  &lexy.Description{Kind: "int8"},
+		Value: &lexy.Description{
+			Kind:       "slice",
+			Elem:       &lexy.Description{Kind: "time"},
+			Negated:    true,
+			Terminated: true,
+		},
+		NilsLast: true,
+	}, lexy.Describe(codec))
+	assert.Equal(t, lexy.Description{Kind: "custom", Params: []any{"lexy_test.quaternionCodec"}},
+		lexy.Describe[Quaternion](quatCodec))
+}
+
+func TestDescribeLayoutChange(t *testing.T) {
+	t.Parallel()
+	// Equivalent encodings have equal descriptions, even when built differently.
+	assert.Equal(t, lexy.Describe(lexy.TimeDesc()), lexy.Describe(lexy.Negate(lexy.Time())))
+	assert.Equal(t, lexy.Describe(lexy.Uint()), lexy.Describe(lexy.Uint64()))
+	assert.Equal(t, lexy.Describe(lexy.Terminate(lexy.String())), lexy.Describe(lexy.TerminatedString()))
+	// Different encodings have different descriptions.
+	assert.NotEqual(t, lexy.Describe(lexy.Enum(1, 2)), lexy.Describe(lexy.Enum(2, 1)))
+	assert.NotEqual(t, lexy.Describe(lexy.TimeMillis()), lexy.Describe(lexy.TimeMicros()))
+	assert.NotEqual(t, lexy.Describe(lexy.Hilbert2D(lexy.Int8(), 4)), lexy.Describe(lexy.Hilbert2D(lexy.Int8(), 5)))
+	pointerCodec := lexy.PointerTo(lexy.Int8())
+	assert.NotEqual(t, lexy.Describe(pointerCodec), lexy.Describe(lexy.NilsLast(pointerCodec)))
+}
+
+func TestDescribeSchemaEncoding(t *testing.T) {
+	t.Parallel()
+	codec, err := lexy.ParseSchema("tuple(uint32, desc(time), terminated(string), slice(int64))")
+	require.NoError(t, err)
+	again, err := lexy.ParseSchema(lexy.Describe(codec).String())
+	require.NoError(t, err)
+	value := []any{uint32(1), time.Unix(0, 0), "a", []any{int64(2)}}
+	assert.Equal(t, codec.Append(nil, value), again.Append(nil, value))
+}
+
+func TestDescribeSchema(t *testing.T) {
+	t.Parallel()
+	for _, schema := range []string{
+		"tuple(uint32, desc(time), terminated(string), slice(int64))",
+		"nils_last(slice(nils_last(bytes)))",
+		"map(terminated(folded_string), optional(date_time))",
+		"desc(tuple(terminated(natural_string), shortlex_slice(big_rat)))",
+		"nils_last(optional(desc(string)))",
+	} {
+		t.Run(schema, func(t *testing.T) {
+			t.Parallel()
+			codec, err := lexy.ParseSchema(schema)
+			require.NoError(t, err)
+			desc := lexy.Describe(codec)
+			// The description of a parsed schema can be parsed again, into an equivalent Codec.
+			again, err := lexy.ParseSchema(desc.String())
+			require.NoError(t, err)
+			assert.Equal(t, desc, lexy.Describe(again))
+		})
+	}
+}
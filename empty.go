@@ -23,3 +23,7 @@ func (emptyCodec[T]) Get(buf []byte) (T, []byte) {
 func (emptyCodec[T]) RequiresTerminator() bool {
 	return true
 }
+
+func (emptyCodec[T]) Describe() Description {
+	return Description{Kind: "empty"}
+}
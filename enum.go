@@ -0,0 +1,106 @@
+package lexy
+
+import "math/bits"
+
+// enumCodec is the Codec for a fixed set of values of type T, in an explicit order.
+//
+// A known value is encoded as its index in values, in width big-endian bytes.
+// width is the smallest number of bytes that can hold every index and one more value, fallbackMarker,
+// which is all 1 bits and therefore greater than every index.
+// If fallback is not nil, any other value is encoded as fallbackMarker followed by its encoding by fallback,
+// so all unknown values are ordered after all known values, in the order produced by fallback.
+// If fallback is nil, Append and Put will panic on unknown values.
+//
+// Because the index has a fixed width, and fallbackMarker cannot be a known index,
+// this Codec requires escaping if and only if fallback does.
+type enumCodec[T comparable] struct {
+	values   []T
+	indexes  map[T]uint64
+	width    int
+	fallback Codec[T]
+}
+
+func newEnumCodec[T comparable](fallback Codec[T], values []T) enumCodec[T] {
+	indexes := make(map[T]uint64, len(values))
+	for i, value := range values {
+		if _, ok := indexes[value]; ok {
+			panic(duplicateEnumValueError{value})
+		}
+		indexes[value] = uint64(i)
+	}
+	width := max(1, numBytes(bits.Len(uint(len(values)))))
+	return enumCodec[T]{append([]T{}, values...), indexes, width, fallback}
+}
+
+func (c enumCodec[T]) fallbackMarker() uint64 {
+	return 1<<(c.width*bitsPerByte) - 1
+}
+
+// index returns the index to encode for value, and whether value is known.
+func (c enumCodec[T]) index(value T) (uint64, bool) {
+	if i, ok := c.indexes[value]; ok {
+		return i, true
+	}
+	if c.fallback == nil {
+		panic(unknownEnumValueError{value})
+	}
+	return c.fallbackMarker(), false
+}
+
+func (c enumCodec[T]) putIndex(buf []byte, index uint64) {
+	_ = buf[c.width-1] // check that we have room
+	for i := range c.width {
+		buf[i] = byte(index >> ((c.width - 1 - i) * bitsPerByte))
+	}
+}
+
+func (c enumCodec[T]) Append(buf []byte, value T) []byte {
+	index, known := c.index(value)
+	buf = append(buf, make([]byte, c.width)...)
+	c.putIndex(buf[len(buf)-c.width:], index)
+	if known {
+		return buf
+	}
+	return c.fallback.Append(buf, value)
+}
+
+func (c enumCodec[T]) Put(buf []byte, value T) []byte {
+	index, known := c.index(value)
+	c.putIndex(buf, index)
+	if known {
+		return buf[c.width:]
+	}
+	return c.fallback.Put(buf[c.width:], value)
+}
+
+func (c enumCodec[T]) Get(buf []byte) (T, []byte) {
+	_ = buf[c.width-1] // check that we have enough
+	var index uint64
+	for _, b := range buf[:c.width] {
+		index = index<<bitsPerByte | uint64(b)
+	}
+	buf = buf[c.width:]
+	if index < uint64(len(c.values)) {
+		return c.values[index], buf
+	}
+	if index == c.fallbackMarker() && c.fallback != nil {
+		return c.fallback.Get(buf)
+	}
+	panic(unknownEnumIndexError{index})
+}
+
+func (c enumCodec[T]) RequiresTerminator() bool {
+	return c.fallback != nil && c.fallback.RequiresTerminator()
+}
+
+func (c enumCodec[T]) Describe() Description {
+	params := make([]any, len(c.values))
+	for i, value := range c.values {
+		params[i] = value
+	}
+	d := Description{Kind: "enum", Params: params}
+	if c.fallback != nil {
+		d.Elem = describeRef(c.fallback)
+	}
+	return d
+}
@@ -0,0 +1,120 @@
+package lexy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/phiryll/lexy"
+)
+
+type status string
+
+const (
+	statusNone     status = ""
+	statusPending  status = "pending"
+	statusActive   status = "active"
+	statusArchived status = "archived"
+)
+
+func TestEnum(t *testing.T) {
+	t.Parallel()
+	codec := lexy.Enum(statusPending, statusActive, statusArchived, statusNone)
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[status]{
+		{"pending", statusPending, []byte{0x00}},
+		{"active", statusActive, []byte{0x01}},
+		{"archived", statusArchived, []byte{0x02}},
+		{"none", statusNone, []byte{0x03}},
+	})
+	testOrdering(t, codec, []testCase[status]{
+		{"pending", statusPending, nil},
+		{"active", statusActive, nil},
+		{"archived", statusArchived, nil},
+		{"none", statusNone, nil},
+	})
+}
+
+func TestEnumInt(t *testing.T) {
+	t.Parallel()
+	codec := lexy.Enum(2, 0, 1)
+	testCodec(t, codec, []testCase[int]{
+		{"2", 2, []byte{0x00}},
+		{"0", 0, []byte{0x01}},
+		{"1", 1, []byte{0x02}},
+	})
+}
+
+func TestEnumWidth(t *testing.T) {
+	t.Parallel()
+	values := make([]uint16, 255)
+	for i := range values {
+		values[i] = uint16(1000 - i)
+	}
+	codec := lexy.Enum(values...)
+	assert.Equal(t, []byte{0xFE}, codec.Append(nil, 1000-254))
+
+	values = append(values, 0)
+	codec = lexy.Enum(values...)
+	testCodec(t, codec, []testCase[uint16]{
+		{"first", 1000, []byte{0x00, 0x00}},
+		{"index 254", 1000 - 254, []byte{0x00, 0xFE}},
+		{"last", 0, []byte{0x00, 0xFF}},
+	})
+	testOrdering(t, codec, []testCase[uint16]{
+		{"1000", 1000, nil},
+		{"999", 999, nil},
+		{"747", 747, nil},
+		{"0", 0, nil},
+	})
+}
+
+func TestEnumPanics(t *testing.T) {
+	t.Parallel()
+	assert.PanicsWithError(t, "duplicate enum value active", func() {
+		lexy.Enum(statusActive, statusPending, statusActive)
+	})
+	codec := lexy.Enum(statusPending, statusActive)
+	assert.PanicsWithError(t, "unknown enum value archived", func() {
+		codec.Append(nil, statusArchived)
+	})
+	assert.PanicsWithError(t, "unknown enum value archived", func() {
+		codec.Put(make([]byte, 10), statusArchived)
+	})
+	assert.PanicsWithError(t, "unknown enum index 2", func() {
+		codec.Get([]byte{0x02})
+	})
+	assert.PanicsWithError(t, "unknown enum index 255", func() {
+		codec.Get([]byte{0xFF, 'x'})
+	})
+	assert.Panics(t, func() {
+		lexy.EnumWithFallback[status](nil, statusActive)
+	})
+}
+
+func TestEnumWithFallback(t *testing.T) {
+	t.Parallel()
+	codec := lexy.EnumWithFallback(lexy.CastString[status](), statusPending, statusActive)
+	assert.True(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[status]{
+		{"pending", statusPending, []byte{0x00}},
+		{"active", statusActive, []byte{0x01}},
+		{"archived", statusArchived, []byte{0xFF, 'a', 'r', 'c', 'h', 'i', 'v', 'e', 'd'}},
+		{"none", statusNone, []byte{0xFF}},
+	})
+	testOrdering(t, codec, []testCase[status]{
+		{"pending", statusPending, nil},
+		{"active", statusActive, nil},
+		{"none", statusNone, nil},
+		{"archived", statusArchived, nil},
+		{"deleted", "deleted", nil},
+	})
+
+	terminated := lexy.EnumWithFallback(lexy.Terminate(lexy.CastString[status]()), statusPending, statusNone)
+	assert.False(t, terminated.RequiresTerminator())
+	testCodec(t, terminated, []testCase[status]{
+		{"pending", statusPending, []byte{0x00}},
+		{"none", statusNone, []byte{0x01}},
+		{"active", statusActive, []byte{0xFF, 'a', 'c', 't', 'i', 'v', 'e', term}},
+	})
+}
@@ -10,6 +10,11 @@ var (
 	errUnexpectedNilsFirst = errors.New("read nils-first prefix when nils-last was configured")
 	errUnexpectedNilsLast  = errors.New("read nils-last prefix when nils-first was configured")
 	errBigFloatEncoding    = errors.New("unexpected failure encoding big.Float")
+	errNilCollator         = errors.New("nil collator")
+	errEmptySeparator      = errors.New("separator must not be empty")
+	errBadBitSetEncoding   = errors.New("invalid bit set encoding")
+	errNegativeSize        = errors.New("size must not be negative")
+	errNilsLastSchema      = errors.New("argument does not encode nils")
 )
 
 type unknownPrefixError struct {
@@ -27,3 +32,121 @@ type badTypeError struct {
 func (e badTypeError) Error() string {
 	return fmt.Sprintf("bad type %T", e.value)
 }
+
+type versionSyntaxError struct {
+	version string
+	reason  string
+}
+
+func (e versionSyntaxError) Error() string {
+	return fmt.Sprintf("invalid semantic version %q: %s", e.version, e.reason)
+}
+
+type unknownTagError struct {
+	tag byte
+}
+
+func (e unknownTagError) Error() string {
+	return fmt.Sprintf("unknown variant tag 0x%X", e.tag)
+}
+
+type duplicateTagError struct {
+	tag byte
+}
+
+func (e duplicateTagError) Error() string {
+	return fmt.Sprintf("duplicate variant tag 0x%X", e.tag)
+}
+
+type noVariantError struct {
+	value any
+}
+
+func (e noVariantError) Error() string {
+	return fmt.Sprintf("no variant matches value of type %T", e.value)
+}
+
+type duplicateEnumValueError struct {
+	value any
+}
+
+func (e duplicateEnumValueError) Error() string {
+	return fmt.Sprintf("duplicate enum value %v", e.value)
+}
+
+type unknownEnumValueError struct {
+	value any
+}
+
+func (e unknownEnumValueError) Error() string {
+	return fmt.Sprintf("unknown enum value %v", e.value)
+}
+
+type unknownEnumIndexError struct {
+	index uint64
+}
+
+func (e unknownEnumIndexError) Error() string {
+	return fmt.Sprintf("unknown enum index %d", e.index)
+}
+
+type bitSetLengthError struct {
+	size   int
+	length int
+}
+
+func (e bitSetLengthError) Error() string {
+	return fmt.Sprintf("bit set of length %d is longer than %d", e.length, e.size)
+}
+
+type bitsRangeError struct {
+	bits    int
+	maxBits int
+}
+
+func (e bitsRangeError) Error() string {
+	return fmt.Sprintf("bits must be between 1 and %d, got %d", e.maxBits, e.bits)
+}
+
+type geoPointRangeError struct {
+	point GeoPoint
+}
+
+func (e geoPointRangeError) Error() string {
+	return fmt.Sprintf("latitude must be within [-90, 90] and longitude within [-180, 180], got %v", e.point)
+}
+
+type schemaSyntaxError struct {
+	schema string
+	offset int
+	reason string
+}
+
+func (e schemaSyntaxError) Error() string {
+	return fmt.Sprintf("invalid schema %q at offset %d: %s", e.schema, e.offset, e.reason)
+}
+
+type tupleTerminatorError struct {
+	index int
+}
+
+func (e tupleTerminatorError) Error() string {
+	return fmt.Sprintf("element %d requires a terminator but is not last, use terminated(...)", e.index)
+}
+
+type mapKeyError struct {
+	schema string
+}
+
+func (e mapKeyError) Error() string {
+	return fmt.Sprintf("key %s is not comparable", e.schema)
+}
+
+type tupleLengthError struct {
+	size   int
+	length int
+}
+
+func (e tupleLengthError) Error() string {
+	return fmt.Sprintf("tuple of length %d must have length %d", e.length, e.size)
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"math/big"
 	"reflect"
+	"slices"
 	"time"
 
 	"github.com/phiryll/lexy"
@@ -179,6 +180,78 @@ func ExampleTime() {
 	// 2000-01-02T03:04:05.678901234Z
 }
 
+func ExampleNewerThan() {
+	codec := lexy.TimeUTCDesc()
+	start := time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+	var keys [][]byte
+	for i := range 5 {
+		keys = append(keys, codec.Append(nil, start.Add(time.Duration(i)*time.Hour)))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	// Everything newer than 05:04:05, newest first.
+	end := lexy.NewerThan(codec, start.Add(2*time.Hour))
+	for _, key := range keys {
+		if bytes.Compare(key, end) >= 0 {
+			break
+		}
+		value, _ := codec.Get(key)
+		fmt.Println(value.Format(time.TimeOnly))
+	}
+	// Output:
+	// 07:04:05
+	// 06:04:05
+}
+
+func ExampleCivilDate() {
+	codec := lexy.CivilDate()
+	when := time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+	buf := codec.Append(nil, lexy.DateOf(when.In(time.FixedZone("", -5*3600))))
+	decoded, _ := codec.Get(buf)
+	fmt.Println(len(buf))
+	fmt.Println(decoded)
+	fmt.Println(decoded.In(time.UTC).Format(time.RFC3339))
+	// Output:
+	// 4
+	// 2000-01-01
+	// 2000-01-01T00:00:00Z
+}
+
+func ExampleZOrder2Ranges() {
+	codec := lexy.ZOrder2(lexy.Int32())
+	var keys [][]byte
+	for x := range int32(8) {
+		for y := range int32(8) {
+			keys = append(keys, codec.Append(nil, lexy.Point2[int32]{x, y}))
+		}
+	}
+	slices.SortFunc(keys, bytes.Compare)
+
+	// Scan each range for points in the box, filtering out any outside it.
+	lo, hi := lexy.Point2[int32]{2, 3}, lexy.Point2[int32]{4, 4}
+	ranges := lexy.ZOrder2Ranges(codec, lo, hi, 4)
+	fmt.Println(len(ranges))
+	for _, r := range ranges {
+		for _, key := range keys {
+			if !r.Contains(key) {
+				continue
+			}
+			p, _ := codec.Get(key)
+			if lo.X <= p.X && p.X <= hi.X && lo.Y <= p.Y && p.Y <= hi.Y {
+				fmt.Println(p)
+			}
+		}
+	}
+	// Output:
+	// 4
+	// {2 3}
+	// {3 3}
+	// {2 4}
+	// {3 4}
+	// {4 3}
+	// {4 4}
+}
+
 func ExampleBigInt() {
 	codec := lexy.BigInt()
 	var value big.Int
@@ -122,6 +122,10 @@ func (float32Codec) RequiresTerminator() bool {
 	return false
 }
 
+func (float32Codec) Describe() Description {
+	return Description{Kind: "float32"}
+}
+
 func (float64Codec) Append(buf []byte, value float64) []byte {
 	return stdUint64.Append(buf, float64ToBits(value))
 }
@@ -138,3 +142,7 @@ func (float64Codec) Get(buf []byte) (float64, []byte) {
 func (float64Codec) RequiresTerminator() bool {
 	return false
 }
+
+func (float64Codec) Describe() Description {
+	return Description{Kind: "float64"}
+}
@@ -0,0 +1,27 @@
+package lexy
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// caseFolder is the Collator used by FoldedString.
+//
+// The collation key is the string with every rune r replaced by unicode.ToLower(unicode.ToUpper(r)).
+// This is a close approximation of Unicode simple case folding, using only the standard library.
+// Bytes which are not part of a valid UTF-8 encoding are copied to the key unchanged.
+type caseFolder struct{}
+
+func (caseFolder) AppendKey(buf []byte, value string) []byte {
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		if r == utf8.RuneError && size <= 1 {
+			buf = append(buf, value[i])
+			i++
+			continue
+		}
+		buf = utf8.AppendRune(buf, unicode.ToLower(unicode.ToUpper(r)))
+		i += size
+	}
+	return buf
+}
@@ -0,0 +1,96 @@
+package lexy_test
+
+import (
+	"cmp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/phiryll/lexy"
+)
+
+// Implements the ordering semantics of FoldedString without encoding.
+func cmpFolded(a, b string) int {
+	fold := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			return []rune(strings.ToLower(strings.ToUpper(string(r))))[0]
+		}, s)
+	}
+	if c := cmp.Compare(fold(a), fold(b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(a, b)
+}
+
+func TestFoldedString(t *testing.T) {
+	t.Parallel()
+	codec := lexy.FoldedString()
+	assert.True(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[string]{
+		{"empty", "", []byte{term}},
+		{"abc", "abc", []byte{'a', 'b', 'c', term, 'a', 'b', 'c'}},
+		{"AbC", "AbC", []byte{'a', 'b', 'c', term, 'A', 'b', 'C'}},
+		{"Σ", "Σ", []byte{0xCF, 0x83, term, 0xCE, 0xA3}},
+		{"\\x00", "\x00", []byte{esc, 0x00, term, 0x00}},
+		{"invalid", "A\xFF", []byte{'a', 0xFF, term, 'A', 0xFF}},
+	})
+}
+
+func TestFoldedStringOrdering(t *testing.T) {
+	t.Parallel()
+	testCases := []testCase[string]{
+		{"empty", "", nil},
+		{"\\x00", "\x00", nil},
+		{"@", "@", nil},
+		{"_", "_", nil},
+		{"A", "A", nil},
+		{"a", "a", nil},
+		{"ab", "ab", nil},
+		{"B", "B", nil},
+		{"b", "b", nil},
+		{"GO", "GO", nil},
+		{"Go", "Go", nil},
+		{"go", "go", nil},
+		{"Go!", "Go!", nil},
+		{"Zebra", "Zebra", nil},
+		{"zz", "zz", nil},
+		{"ΣΑΣ", "ΣΑΣ", nil},
+		{"σας", "σας", nil},
+	}
+	for i := range testCases[1:] {
+		assert.Equal(t, -1, cmpFolded(testCases[i].value, testCases[i+1].value))
+	}
+	testOrdering(t, lexy.FoldedString(), testCases)
+	testOrdering(t, lexy.Terminate(lexy.FoldedString()), testCases)
+}
+
+func TestFoldedStringComposes(t *testing.T) {
+	t.Parallel()
+	negated := lexy.Negate(lexy.FoldedString())
+	assert.False(t, negated.RequiresTerminator())
+	testCodec(t, negated, fillTestData(negated, []testCase[string]{
+		{"empty", "", nil},
+		{"mixed", "Hello, World", nil},
+	}))
+	testOrdering(t, negated, []testCase[string]{
+		{"b", "b", nil},
+		{"B", "B", nil},
+		{"ab", "ab", nil},
+		{"a", "a", nil},
+		{"A", "A", nil},
+		{"empty", "", nil},
+	})
+
+	codec := lexy.SliceOf(lexy.FoldedString())
+	testCodec(t, codec, fillTestData(codec, []testCase[[]string]{
+		{"slice", []string{"README", "", "readme", "\x00\x01"}, nil},
+	}))
+	testOrdering(t, codec, []testCase[[]string]{
+		{"[A, b]", []string{"A", "b"}, nil},
+		{"[a]", []string{"a"}, nil},
+		{"[a, B]", []string{"a", "B"}, nil},
+		{"[a, b]", []string{"a", "b"}, nil},
+		{"[B]", []string{"B"}, nil},
+	})
+}
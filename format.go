@@ -0,0 +1,214 @@
+package lexy
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format returns a human-readable form of the value encoded by codec at the start of buf, for debugging.
+// Slices are written as [a, b], maps as {k: v}, pointers as &v, Optionals as Some(v) or None,
+// [OneOf] values as tag:v, and schema tuples as (a, b). Nils are written as nil, strings are quoted,
+// and times are written in RFC 3339 format. Other values are written as if by [fmt.Sprint].
+// If buf has bytes following the encoded value, they are written in hex after the value.
+//
+// Format will panic if codec cannot decode buf, exactly as codec.Get would.
+// Use [FormatLenient] for buffers which might not be valid encodings.
+func Format[T any](codec Codec[T], buf []byte) string {
+	return formatBuf(codec, buf, false).String()
+}
+
+// FormatLenient is like [Format], except that it never panics.
+// Instead, an undecodable part of buf is written with its offset, the bytes which could not be decoded,
+// and the reason, like this:
+//
+//	<undecodable at offset 12: 0102FF: reason>
+//
+// This is preceded by whatever could be decoded before it, and nothing after it is decoded.
+// For example, if a slice's third element is undecodable, the first two elements are still written.
+// This works through the Codecs returned by [Terminate], [Negate], [SliceOf], [MapOf], and the like,
+// but the parts of a Codec not provided by lexy are decoded all at once.
+// Within an escaped and terminated encoding, the offset is that of the start of that encoding,
+// and the bytes written are unescaped, because the unescaped bytes do not correspond to those of buf.
+func FormatLenient[T any](codec Codec[T], buf []byte) string {
+	return formatBuf(codec, buf, true).String()
+}
+
+// FormatPartial is like [FormatLenient], except that it writes "..." in place of an undecodable part of buf,
+// and also returns a *[DecodeError] describing that part. The error is nil if buf can be decoded.
+func FormatPartial[T any](codec Codec[T], buf []byte) (string, error) {
+	out := formatBuf(codec, buf, true)
+	s := out.String()
+	if !out.failed {
+		return s, nil
+	}
+	return s[:out.failure.start] + "..." + s[out.failure.end:], &DecodeError{out.failure.offset, out.failure.reason}
+}
+
+// DecodeError describes the part of a buffer which could not be decoded, as returned by [FormatPartial].
+type DecodeError struct {
+	// Offset is the offset of the undecodable part within the buffer, as written by [FormatLenient].
+	Offset int
+
+	// Reason is the value the Codec panicked with.
+	Reason any
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("undecodable at offset %d: %v", e.Offset, e.Reason)
+}
+
+func formatBuf[T any](codec Codec[T], buf []byte, lenient bool) *formatOutput {
+	f := formatState{&formatOutput{buf: buf, lenient: lenient}, buf, 0, true}
+	if rest := formatPart(&f, codec, buf); len(rest) > 0 {
+		fmt.Fprintf(f.out, " <%d trailing bytes at offset %d: %X>", len(rest), f.offset(rest), rest)
+	}
+	return f.out
+}
+
+// formatter is implemented by Codecs which format their encodings part by part,
+// so that FormatLenient can write the parts preceding an undecodable part.
+// Implementations should format each delegated part with formatPart.
+type formatter interface {
+	format(f *formatState, buf []byte) []byte
+}
+
+// formatOutput is the output of a Format or FormatLenient call formatting buf.
+// failed is set when FormatLenient encounters an undecodable part, after which nothing more is decoded,
+// and failure describes that part.
+type formatOutput struct {
+	strings.Builder
+	buf     []byte
+	lenient bool
+	failed  bool
+	failure formatFailure
+}
+
+// formatFailure describes the undecodable part of a buffer, and where its annotation is in the output.
+type formatFailure struct {
+	offset     int
+	reason     any
+	start, end int
+}
+
+// formatState is the state of a Format or FormatLenient call while formatting part of the buffer.
+// Offsets are computed relative to origin, which starts at base within the buffer being formatted.
+// If exact is false, origin is a transformed copy whose offsets do not correspond to the formatted buffer,
+// so every offset within it is reported as base.
+type formatState struct {
+	out    *formatOutput
+	origin []byte
+	base   int
+	exact  bool
+}
+
+// offset returns the offset of buf, which must be a suffix of f.origin, within the buffer being formatted.
+func (f *formatState) offset(buf []byte) int {
+	if !f.exact {
+		return f.base
+	}
+	return f.base + len(f.origin) - len(buf)
+}
+
+// within returns the formatState for origin, a transformed copy of the encoding starting at buf.
+// If exact is true, the offsets in origin correspond to those in buf.
+func (f *formatState) within(buf, origin []byte, exact bool) *formatState {
+	return &formatState{f.out, origin, f.offset(buf), exact && f.exact}
+}
+
+func (f *formatState) write(s string) {
+	f.out.WriteString(s)
+}
+
+// original returns the bytes of the buffer being formatted corresponding to buf, a suffix of f.origin,
+// or buf itself if they do not correspond.
+func (f *formatState) original(buf []byte) []byte {
+	if !f.exact {
+		return buf
+	}
+	start := f.offset(buf)
+	return f.out.buf[start : start+len(buf)]
+}
+
+// more returns true if buf is not empty, and nothing has failed to decode.
+func (f *formatState) more(buf []byte) bool {
+	return len(buf) > 0 && !f.out.failed
+}
+
+// formatPart writes the value encoded by codec at the start of buf, returning the rest of buf.
+// If f is lenient and the value cannot be decoded, this writes what could not be decoded and returns nil,
+// and does nothing if a previous part could not be decoded.
+//
+//nolint:nonamedreturns
+func formatPart[T any](f *formatState, codec Codec[T], buf []byte) (rest []byte) {
+	if f.out.failed {
+		return nil
+	}
+	if f.out.lenient {
+		defer func() {
+			if r := recover(); r != nil {
+				start := f.out.Len()
+				if len(buf) == 0 {
+					fmt.Fprintf(f.out, "<undecodable at offset %d: unexpected end: %v>", f.offset(buf), r)
+				} else {
+					fmt.Fprintf(f.out, "<undecodable at offset %d: %X: %v>", f.offset(buf), f.original(buf), r)
+				}
+				f.out.failed = true
+				f.out.failure = formatFailure{f.offset(buf), r, start, f.out.Len()}
+				rest = nil
+			}
+		}()
+	}
+	if c, ok := codec.(formatter); ok {
+		return c.format(f, buf)
+	}
+	value, rest := codec.Get(buf)
+	f.write(formatValue(value))
+	return rest
+}
+
+// formatNillable writes nil and returns true if prefix decodes a nil at the start of buf,
+// and otherwise returns false and buf following the prefix.
+func formatNillable(f *formatState, prefix Prefix, buf []byte) (bool, []byte) {
+	done, buf := prefix.Get(buf)
+	if done {
+		f.write("nil")
+	}
+	return done, buf
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return strconv.Quote(v)
+	case []byte:
+		if v == nil {
+			return "nil"
+		}
+		return strconv.Quote(string(v))
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case *big.Int:
+		return formatBig(v)
+	case *big.Float:
+		return formatBig(v)
+	case *big.Rat:
+		return formatBig(v)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func formatBig[T interface {
+	*big.Int | *big.Float | *big.Rat
+	String() string
+}](value T) string {
+	if value == nil {
+		return "nil"
+	}
+	return value.String()
+}
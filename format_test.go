@@ -0,0 +1,157 @@
+package lexy_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+// formatted returns the formatted encoding of value by codec.
+func formatted[T any](codec lexy.Codec[T], value T) string {
+	return lexy.Format(codec, codec.Append(nil, value))
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+	schemaCodec, err := lexy.ParseSchema("tuple(uint8, desc(terminated(string)), optional(int8))")
+	require.NoError(t, err)
+	oneOf := lexy.OneOf(
+		lexy.VariantOf[myStringer](1, lexy.SemVer()),
+		lexy.VariantOf[myStringer](2, lexy.CivilDate()),
+	)
+	when := time.Date(2024, 2, 29, 1, 2, 3, 4, time.UTC)
+	for _, tt := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"int32", formatted(lexy.Int32(), -5), "-5"},
+		{"string", formatted(lexy.String(), "a\"b"), `"a\"b"`},
+		{"bytes", formatted(lexy.Bytes(), []byte{'a', 0}), `"a\x00"`},
+		{"nil bytes", formatted(lexy.Bytes(), nil), "nil"},
+		{"time", formatted(lexy.Time(), when), "2024-02-29T01:02:03.000000004Z"},
+		{"big int", formatted(lexy.BigInt(), big.NewInt(-12)), "-12"},
+		{"nil big float", formatted(lexy.BigFloat(), nil), "nil"},
+		{"slice", formatted(lexy.SliceOf(lexy.String()), []string{"a", ""}), `["a", ""]`},
+		{"nil slice", formatted(lexy.SliceOf(lexy.String()), nil), "nil"},
+		{"empty slice", formatted(lexy.SliceOf(lexy.String()), []string{}), "[]"},
+		{"shortlex slice", formatted(lexy.ShortLexSliceOf(lexy.Int8()), []int8{1, 2}), "[1, 2]"},
+		{"map", formatted(lexy.MapOf(lexy.String(), lexy.Bool()), map[string]bool{"k": true}), `{"k": true}`},
+		{"pointer", formatted(lexy.PointerTo(lexy.Uint16()), ptr(uint16(7))), "&7"},
+		{"nil pointer", formatted(lexy.NilsLast(lexy.PointerTo(lexy.Uint16())), nil), "nil"},
+		{"optional", formatted(lexy.OptionalOf(lexy.String()), lexy.Some("x")), `Some("x")`},
+		{"absent", formatted(lexy.OptionalOf(lexy.String()), lexy.Optional[string]{}), "None"},
+		{"negate", formatted(lexy.Negate(lexy.SliceOf(lexy.Int8())), []int8{-1, 1}), "[-1, 1]"},
+		{"terminate", formatted(lexy.Terminate(lexy.MapOf(lexy.Int8(), lexy.Uint8())), map[int8]uint8{1: 2}), "{1: 2}"},
+		{"one of", formatted[myStringer](oneOf, lexy.Date{Year: 2024, Month: 3, Day: 1}), "2:2024-03-01"},
+		{"schema", formatted[any](schemaCodec, []any{uint8(1), "b", nil}), `(1, "b", None)`},
+		{"custom", formatted[Quaternion](quatCodec, Quaternion{1, 2, 3, 4}), "[1 2 3 4]"},
+		{"trailing", lexy.Format(lexy.Int8(), []byte{0x81, 0xAB, 0xCD}), "1 <2 trailing bytes at offset 1: ABCD>"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.got)
+		})
+	}
+}
+
+func TestFormatPanics(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() {
+		lexy.Format(lexy.Int32(), []byte{0x80})
+	})
+	assert.Panics(t, func() {
+		lexy.Format(lexy.SliceOf(lexy.Int32()), []byte{0x03, 0x80, 0x00, 0x00, 0x01, 0x80})
+	})
+}
+
+func TestFormatLenient(t *testing.T) {
+	t.Parallel()
+	slice := lexy.SliceOf(lexy.Int32())
+	sliceBuf := slice.Append(nil, []int32{1, 2})
+	negSlice := lexy.Negate(slice)
+	negSliceBuf := negSlice.Append(nil, []int32{1, 2})
+	nested := lexy.MapOf(lexy.String(), lexy.SliceOf(lexy.Int8()))
+	nestedBuf := nested.Append(nil, map[string][]int8{"a": {1, 2}})
+	schemaTuple, err := lexy.ParseSchema("tuple(uint32, int8, string)")
+	require.NoError(t, err)
+	negElems := lexy.ShortLexSliceOf(lexy.Negate(lexy.Int16()))
+	negElemsBuf := negElems.Append(nil, []int16{1, 2, 3})
+	for _, tt := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"valid", lexy.FormatLenient(slice, sliceBuf), "[1, 2]"},
+		{"empty", lexy.FormatLenient(lexy.Int32(), []byte{}), "<undecodable at offset 0: unexpected end: "},
+		{"bad prefix", lexy.FormatLenient(slice, []byte{0x07}), "<undecodable at offset 0: 07: "},
+		{
+			"bad element",
+			lexy.FormatLenient(slice, append(sliceBuf, 0x01, 0x02)),
+			"[1, 2, <undecodable at offset 9: 0102: ",
+		},
+		{
+			"negated",
+			lexy.FormatLenient(negSlice, negSliceBuf[:len(negSliceBuf)-1]),
+			"<undecodable at offset 0: FC7FFEFFFEFFFEFE7FFEFFFEFFFD: no unescaped terminator found>",
+		},
+		{
+			"negated trailing",
+			lexy.FormatLenient(negSlice, append(negSliceBuf, 0x01)),
+			"[1, 2] <1 trailing bytes at offset 15: 01>",
+		},
+		{
+			"nested",
+			lexy.FormatLenient(nested, nestedBuf[:len(nestedBuf)-2]),
+			`{"a": <undecodable at offset 3: 0381: no unescaped terminator found>}`,
+		},
+		{
+			"tuple",
+			lexy.FormatLenient(schemaTuple, schemaTuple.Append(nil, []any{uint32(7), int8(1), "a"})[:4]),
+			"(7, <undecodable at offset 4: unexpected end: ",
+		},
+		{
+			"bad key",
+			lexy.FormatLenient(nested, []byte{0x03, 'a'}),
+			`{<undecodable at offset 1: 61: no unescaped terminator found>}`,
+		},
+		{
+			"negated elements",
+			lexy.FormatLenient(negElems, negElemsBuf[:len(negElemsBuf)-1]),
+			"[1, 2, <undecodable at offset 7: 7F: ",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Contains(t, tt.got, tt.want)
+		})
+	}
+}
+
+func TestFormatPartial(t *testing.T) {
+	t.Parallel()
+	codec, err := lexy.ParseSchema("tuple(uint32, slice(int32))")
+	require.NoError(t, err)
+	buf := codec.Append(nil, []any{uint32(7), []any{int32(1), int32(2)}})
+
+	got, err := lexy.FormatPartial(codec, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "(7, [1, 2])", got)
+
+	got, err = lexy.FormatPartial(codec, buf[:len(buf)-1])
+	assert.Equal(t, "(7, [1, ...])", got)
+	var decodeErr *lexy.DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, 9, decodeErr.Offset)
+	assert.ErrorContains(t, err, "undecodable at offset 9: ")
+
+	got, err = lexy.FormatPartial(lexy.Int32(), []byte{})
+	assert.Equal(t, "...", got)
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, 0, decodeErr.Offset)
+}
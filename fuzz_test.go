@@ -395,3 +395,20 @@ func FuzzCmpTerminateBytes(f *testing.F) {
 	addUnorderedPairs(f, seedsBytes...)
 	f.Fuzz(fuzzTargetForPair(lexy.Terminate(lexy.Bytes()), cmpBytes))
 }
+
+func FuzzNaturalString(f *testing.F) {
+	addValues(f, seedsString...)
+	addValues(f, "0", "007", "a1b22c333", "99999999999999999999999")
+	f.Fuzz(fuzzTargetForValue(lexy.NaturalString()))
+}
+
+func FuzzCmpNaturalString(f *testing.F) {
+	addUnorderedPairs(f, append(seedsString, "0", "00", "1", "01", "a2", "a10", "a01b")...)
+	f.Fuzz(fuzzTargetForPair(lexy.NaturalString(), cmpNatural))
+}
+
+func FuzzFoldedString(f *testing.F) {
+	addValues(f, seedsString...)
+	addValues(f, "ABC", "Straße", "ΣΑΣ")
+	f.Fuzz(fuzzTargetForValue(lexy.FoldedString()))
+}
@@ -0,0 +1,219 @@
+package lexy_test
+
+import (
+	"math"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/phiryll/lexy"
+	"github.com/phiryll/lexy/lexytest"
+)
+
+// These tests lock down the encodings of every Codec provided by lexy, so that persisted data stays readable.
+// If an encoding changes deliberately, regenerate the golden files with:
+//
+//	go test . -run TestGolden -lexytest.update
+
+func golden[T any](t *testing.T, name string, codec lexy.Codec[T], values []T, equal func(a, b T) bool) {
+	t.Run(name, func(t *testing.T) {
+		t.Parallel()
+		lexytest.Golden(t, codec, filepath.Join("testdata", "golden", name+".hex"), values, equal)
+	})
+}
+
+// Equality functions for types which reflect.DeepEqual doesn't compare as needed.
+
+func equalFloat32Bits(a, b float32) bool {
+	return math.Float32bits(a) == math.Float32bits(b)
+}
+
+func equalFloat64Bits(a, b float64) bool {
+	return math.Float64bits(a) == math.Float64bits(b)
+}
+
+// For Codecs which do not encode the timezone.
+func equalInstant(a, b time.Time) bool {
+	return a.Equal(b)
+}
+
+func equalTime(a, b time.Time) bool {
+	_, aOffset := a.Zone()
+	_, bOffset := b.Zone()
+	return a.Equal(b) && aOffset == bOffset
+}
+
+func equalZonedTime(a, b time.Time) bool {
+	return equalTime(a, b) && a.Location().String() == b.Location().String()
+}
+
+func equalVersion(a, b lexy.Version) bool {
+	return a.String() == b.String()
+}
+
+func equalBig[T interface {
+	comparable
+	Cmp(T) int
+}](a, b T) bool {
+	var null T
+	if a == null || b == null {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
+
+func TestGoldenNumbers(t *testing.T) {
+	t.Parallel()
+	golden(t, "Empty", lexy.Empty[int](), []int{0}, nil)
+	golden(t, "Bool", lexy.Bool(), []bool{false, true}, nil)
+	golden(t, "Uint", lexy.Uint(), []uint{0, 1, math.MaxUint32, math.MaxUint}, nil)
+	golden(t, "Uint8", lexy.Uint8(), []uint8{0, 1, 0x7F, 0x80, math.MaxUint8}, nil)
+	golden(t, "Uint16", lexy.Uint16(), []uint16{0, 1, 0x1234, math.MaxUint16}, nil)
+	golden(t, "Uint32", lexy.Uint32(), []uint32{0, 1, 0x12345678, math.MaxUint32}, nil)
+	golden(t, "Uint64", lexy.Uint64(), []uint64{0, 1, 0x123456789ABCDEF0, math.MaxUint64}, nil)
+	golden(t, "Int", lexy.Int(), []int{math.MinInt, -1, 0, 1, math.MaxInt}, nil)
+	golden(t, "Int8", lexy.Int8(), []int8{math.MinInt8, -1, 0, 1, math.MaxInt8}, nil)
+	golden(t, "Int16", lexy.Int16(), []int16{math.MinInt16, -1, 0, 1, math.MaxInt16}, nil)
+	golden(t, "Int32", lexy.Int32(), []int32{math.MinInt32, -1, 0, 1, math.MaxInt32}, nil)
+	golden(t, "Int64", lexy.Int64(), []int64{math.MinInt64, -1, 0, 1, math.MaxInt64}, nil)
+	golden(t, "Float32", lexy.Float32(), []float32{
+		float32(math.Inf(-1)), -math.MaxFloat32, -1.5, -math.SmallestNonzeroFloat32, float32(math.Copysign(0, -1)),
+		0, math.SmallestNonzeroFloat32, 1.5, math.MaxFloat32, float32(math.Inf(1)), float32(math.NaN()),
+	}, equalFloat32Bits)
+	golden(t, "Float64", lexy.Float64(), []float64{
+		math.Inf(-1), -math.MaxFloat64, -1.5, -math.SmallestNonzeroFloat64, math.Copysign(0, -1),
+		0, math.SmallestNonzeroFloat64, 1.5, math.MaxFloat64, math.Inf(1), math.NaN(),
+	}, equalFloat64Bits)
+	golden(t, "Complex64", lexy.Complex64(), []complex64{complex(-1, 2), 0, complex(1.5, -2.5)}, nil)
+	golden(t, "Complex128", lexy.Complex128(), []complex128{complex(-1, 2), 0, complex(1.5, -2.5)}, nil)
+	golden(t, "BigInt", lexy.BigInt(), []*big.Int{
+		nil, big.NewInt(-1_000_000), big.NewInt(-1), big.NewInt(0), big.NewInt(1),
+		new(big.Int).Lsh(big.NewInt(1), 100),
+	}, equalBig[*big.Int])
+	golden(t, "BigFloat", lexy.BigFloat(), []*big.Float{
+		nil, big.NewFloat(-1.5), big.NewFloat(0), big.NewFloat(1e-10), big.NewFloat(1.5).SetPrec(200),
+		new(big.Float).SetInf(false),
+	}, equalBig[*big.Float])
+	golden(t, "BigRat", lexy.BigRat(), []*big.Rat{
+		nil, big.NewRat(-7, 3), big.NewRat(0, 1), big.NewRat(1, 3), big.NewRat(5, 1),
+	}, equalBig[*big.Rat])
+}
+
+func TestGoldenStrings(t *testing.T) {
+	t.Parallel()
+	strs := []string{"", "\x00", "\x01", "a", "a\x00b", "abc", "Straße", "\xFF"}
+	golden(t, "String", lexy.String(), strs, nil)
+	golden(t, "TerminatedString", lexy.TerminatedString(), strs, nil)
+	golden(t, "NaturalString", lexy.NaturalString(), []string{"", "a1", "a01", "file2", "file10", "99999999999"}, nil)
+	golden(t, "FoldedString", lexy.FoldedString(), []string{"", "abc", "ABC", "Straße", "ΣΑΣ"}, nil)
+	golden(t, "CollatedString", lexy.CollatedString(testCollator{}), []string{"", "Apple", "apple", "éclair"}, nil)
+	golden(t, "DomainName", lexy.DomainName(), []string{"", "com", "example.com", "www.example.com."}, nil)
+	golden(t, "PathSegments", lexy.PathSegments("/"), []string{"", "/", "/a/b", "a/b/", "a//b"}, nil)
+	golden(t, "ShortLexString", lexy.ShortLexString(), []string{"", "b", "aa", "\x00\xFF"}, nil)
+	bytesValues := [][]byte{nil, {}, {0}, {1, 2, 3}, {0xFF}}
+	golden(t, "Bytes", lexy.Bytes(), bytesValues, nil)
+	golden(t, "TerminatedBytes", lexy.TerminatedBytes(), bytesValues, nil)
+	golden(t, "ShortLexBytes", lexy.ShortLexBytes(), bytesValues, nil)
+	version := func(s string) lexy.Version {
+		v, err := lexy.ParseVersion(s)
+		if err != nil {
+			panic(err)
+		}
+		return v
+	}
+	golden(t, "SemVer", lexy.SemVer(), []lexy.Version{
+		version("0.0.0"), version("1.0.0-alpha"), version("1.0.0-alpha.1"), version("1.0.0-rc.1+build.5"),
+		version("1.0.0"), version("1.10.0+meta"),
+	}, equalVersion)
+	golden(t, "Enum", lexy.Enum("red", "green", "blue"), []string{"red", "green", "blue"}, nil)
+	golden(t, "EnumWithFallback", lexy.EnumWithFallback(lexy.String(), "x", "y"), []string{"x", "y", "", "z"}, nil)
+}
+
+func TestGoldenTimes(t *testing.T) {
+	t.Parallel()
+	plusOne := time.FixedZone("UTC+1", 3600)
+	times := []time.Time{
+		time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 29, 12, 30, 45, 0, plusOne),
+		time.Date(2024, 2, 29, 12, 30, 45, 0, time.UTC),
+	}
+	precise := append(times, time.Date(2024, 2, 29, 12, 30, 45, 123_456_789, time.UTC))
+	golden(t, "Time", lexy.Time(), precise, equalTime)
+	golden(t, "TimeUTC", lexy.TimeUTC(), precise, equalInstant)
+	golden(t, "TimeSeconds", lexy.TimeSeconds(), times, equalInstant)
+	golden(t, "TimeMillis", lexy.TimeMillis(), times, equalInstant)
+	golden(t, "TimeMicros", lexy.TimeMicros(), times, equalInstant)
+	golden(t, "TimeZoned", lexy.TimeZoned(), precise, equalZonedTime)
+	golden(t, "TimeDesc", lexy.TimeDesc(), precise, equalTime)
+	golden(t, "TimeUTCDesc", lexy.TimeUTCDesc(), precise, equalInstant)
+	golden(t, "TimeSecondsDesc", lexy.TimeSecondsDesc(), times, equalInstant)
+	golden(t, "TimeMillisDesc", lexy.TimeMillisDesc(), times, equalInstant)
+	golden(t, "TimeMicrosDesc", lexy.TimeMicrosDesc(), times, equalInstant)
+	durations := []time.Duration{math.MinInt64, -time.Second, 0, time.Nanosecond, time.Hour, math.MaxInt64}
+	golden(t, "Duration", lexy.Duration(), durations, nil)
+	golden(t, "DurationDesc", lexy.DurationDesc(), durations, nil)
+	dates := []lexy.Date{{1, time.January, 1}, {1970, time.January, 1}, {2024, time.February, 29}}
+	golden(t, "CivilDate", lexy.CivilDate(), dates, nil)
+	timesOfDay := []lexy.TimeOfDay{{0, 0, 0, 0}, {12, 30, 45, 123_456_789}, {23, 59, 59, 999_999_999}}
+	golden(t, "CivilTimeOfDay", lexy.CivilTimeOfDay(), timesOfDay, nil)
+	golden(t, "CivilDateTime", lexy.CivilDateTime(), []lexy.DateTime{
+		{dates[0], timesOfDay[0]}, {dates[2], timesOfDay[1]},
+	}, nil)
+}
+
+func TestGoldenContainers(t *testing.T) {
+	t.Parallel()
+	golden(t, "PointerTo", lexy.PointerTo(lexy.Int16()), []*int16{nil, ptr[int16](-1), ptr[int16](0)},
+		nil)
+	golden(t, "PointerToNilsLast", lexy.NilsLast(lexy.PointerTo(lexy.Int16())), []*int16{ptr[int16](0), nil}, nil)
+	golden(t, "OptionalOf", lexy.OptionalOf(lexy.String()), []lexy.Optional[string]{
+		{}, lexy.Some(""), lexy.Some("a"),
+	}, nil)
+	golden(t, "OptionalOfNilsLast", lexy.NilsLast(lexy.OptionalOf(lexy.String())), []lexy.Optional[string]{
+		lexy.Some("a"), {},
+	}, nil)
+	strSlices := [][]string{nil, {}, {""}, {"a", "b"}, {"a\x00"}}
+	golden(t, "SliceOf", lexy.SliceOf(lexy.String()), strSlices, nil)
+	golden(t, "SliceOfNilsLast", lexy.NilsLast(lexy.SliceOf(lexy.String())), strSlices, nil)
+	golden(t, "SliceOfInt32", lexy.SliceOf(lexy.Int32()), [][]int32{nil, {}, {-1, 0, 1}}, nil)
+	golden(t, "ShortLexSliceOf", lexy.ShortLexSliceOf(lexy.String()), strSlices, nil)
+	// Only maps with at most one entry have a consistent encoding.
+	golden(t, "MapOf", lexy.MapOf(lexy.String(), lexy.Int32()), []map[string]int32{
+		nil, {}, {"": 0}, {"a": -1},
+	}, nil)
+	golden(t, "BytesNilsLast", lexy.NilsLast(lexy.Bytes()), [][]byte{{}, {1}, nil}, nil)
+	bitSets := [][]bool{nil, {}, {false}, {true, false, true, true, false, false, true, true}}
+	golden(t, "BitSet", lexy.BitSet(), bitSets, nil)
+	golden(t, "FixedBitSet", lexy.FixedBitSet(10), [][]bool{
+		make([]bool, 10), {true, false, true, true, false, false, true, true, false, true},
+	}, nil)
+	golden(t, "OneOf", lexy.OneOf(
+		lexy.VariantOf[any](1, lexy.Int64()),
+		lexy.VariantOf[any](2, lexy.String()),
+	), []any{int64(-1), int64(1), "", "a"}, nil)
+}
+
+func TestGoldenSpatial(t *testing.T) {
+	t.Parallel()
+	golden(t, "ZOrder2", lexy.ZOrder2(lexy.Uint8()), []lexy.Point2[uint8]{{0, 0}, {1, 2}, {0xFF, 0x0F}}, nil)
+	golden(t, "ZOrder3", lexy.ZOrder3(lexy.Int8()), []lexy.Point3[int8]{{-1, 0, 1}, {0, 0, 0}, {1, -2, 3}}, nil)
+	golden(t, "Hilbert2D", lexy.Hilbert2D(lexy.Uint16(), 8), []lexy.Point2[uint16]{
+		{0, 0}, {0x1234, 0xABCD}, {0xFFFF, 0},
+	}, nil)
+	golden(t, "LatLng", lexy.LatLng(40), []lexy.GeoPoint{
+		{-90, -180}, {0, 0}, {51.5074, -0.1278}, {-33.8688, 151.2093}, {90, 180},
+	}, func(a, b lexy.GeoPoint) bool {
+		// Cells are less than a kilometer across.
+		return a.DistanceMeters(b) < 1000
+	})
+}
+
+func TestGoldenWrappers(t *testing.T) {
+	t.Parallel()
+	golden(t, "NegateInt32", lexy.Negate(lexy.Int32()), []int32{math.MaxInt32, 1, 0, -1, math.MinInt32}, nil)
+	golden(t, "NegateString", lexy.Negate(lexy.String()), []string{"b", "ab", "a", "\x00", ""}, nil)
+	golden(t, "TerminateBytes", lexy.Terminate(lexy.Bytes()), [][]byte{nil, {}, {0, 1, 2}}, nil)
+	golden(t, "TerminateNegateString", lexy.Terminate(lexy.Negate(lexy.String())), []string{"b", "a\x00", ""}, nil)
+}
@@ -0,0 +1,312 @@
+package lexy
+
+import "bytes"
+
+// hilbert2Codec is the Codec for Point2s in Hilbert curve order.
+//
+// Each coordinate is encoded with coord, which must produce fixed-width encodings of width bytes,
+// and the encoding is treated as a big-endian unsigned integer of width*8 bits.
+// The most significant bits of the two encoded coordinates select a cell in a square grid with 2^bits cells per side,
+// and the cells are ordered along a Hilbert curve of order bits.
+// A point is encoded as:
+//
+//	the index of its cell along the curve, 2*bits bits
+//	the remaining low bits of the encoded X coordinate, width*8 - bits bits
+//	the remaining low bits of the encoded Y coordinate, width*8 - bits bits
+//
+// for a total of 2*width bytes. Points in the same cell are ordered by X and then Y.
+// Consecutive cells along a Hilbert curve are always adjacent in space, unlike Z-order,
+// so a query rectangle is typically covered by fewer key ranges.
+// Every encoding is the same length, so this Codec does not require escaping.
+type hilbert2Codec[T any] struct {
+	coord Codec[T]
+	width int
+	bits  int
+}
+
+// maxHilbertBits is the maximum order of the Hilbert curve, so that cell indexes fit in a uint64.
+const maxHilbertBits = 32
+
+func newHilbert2Codec[T any](coord Codec[T], bits int) hilbert2Codec[T] {
+	if coord.RequiresTerminator() {
+		panic(badTypeError{coord})
+	}
+	var zero T
+	width := len(coord.Append(nil, zero))
+	if bits < 1 || bits > maxHilbertBits || bits > width*bitsPerByte {
+		panic(bitsRangeError{bits, min(maxHilbertBits, width*bitsPerByte)})
+	}
+	return hilbert2Codec[T]{coord, width, bits}
+}
+
+func (c hilbert2Codec[T]) size() int {
+	return 2 * c.width //nolint:mnd
+}
+
+func (c hilbert2Codec[T]) coordBits() int {
+	return c.width * bitsPerByte
+}
+
+// topBits returns the n most significant bits of buf, n <= 64.
+func topBits(buf []byte, n int) uint64 {
+	var value uint64
+	for i := range n {
+		value <<= 1
+		if getBit(buf, i) {
+			value |= 1
+		}
+	}
+	return value
+}
+
+// putIndexBits writes the n low bits of value to the first n bits of buf, most significant first.
+func putIndexBits(buf []byte, value uint64, n int) {
+	for i := range n {
+		setBit(buf, i, value&(1<<(n-1-i)) != 0)
+	}
+}
+
+// hilbertRotate rotates and flips the quadrant of x and y, as the curve does within a quadrant.
+func hilbertRotate(n, x, y, rx, ry uint64) (uint64, uint64) {
+	if ry == 0 {
+		if rx == 1 {
+			x = n - 1 - x
+			y = n - 1 - y
+		}
+		x, y = y, x
+	}
+	return x, y
+}
+
+// hilbertIndex returns the index along a Hilbert curve of order bits of the cell (x, y).
+func hilbertIndex(bits int, x, y uint64) uint64 {
+	var d uint64
+	for s := uint64(1) << (bits - 1); s > 0; s >>= 1 {
+		var rx, ry uint64
+		if x&s != 0 {
+			rx = 1
+		}
+		if y&s != 0 {
+			ry = 1
+		}
+		d += s * s * ((3 * rx) ^ ry) //nolint:mnd
+		x, y = hilbertRotate(s, x&(s-1), y&(s-1), rx, ry)
+	}
+	return d
+}
+
+// hilbertCell returns the cell (x, y) at index d along a Hilbert curve of order bits.
+func hilbertCell(bits int, d uint64) (uint64, uint64) {
+	var x, y uint64
+	for s := uint64(1); s < 1<<bits; s <<= 1 {
+		rx := 1 & (d / 2) //nolint:mnd
+		ry := 1 & (d ^ rx)
+		x, y = hilbertRotate(s, x, y, rx, ry)
+		x += s * rx
+		y += s * ry
+		d /= 4
+	}
+	return x, y
+}
+
+func (c hilbert2Codec[T]) encodeCoord(value T) []byte {
+	buf := c.coord.Append(nil, value)
+	if len(buf) != c.width {
+		panic(badTypeError{c.coord})
+	}
+	return buf
+}
+
+func (c hilbert2Codec[T]) Append(buf []byte, value Point2[T]) []byte {
+	buf = append(buf, make([]byte, c.size())...)
+	c.Put(buf[len(buf)-c.size():], value)
+	return buf
+}
+
+func (c hilbert2Codec[T]) Put(buf []byte, value Point2[T]) []byte {
+	size := c.size()
+	_ = buf[size-1] // check that we have room
+	x, y := c.encodeCoord(value.X), c.encodeCoord(value.Y)
+	putIndexBits(buf, hilbertIndex(c.bits, topBits(x, c.bits), topBits(y, c.bits)), 2*c.bits)
+	k := 2 * c.bits
+	for _, coord := range [][]byte{x, y} {
+		for i := c.bits; i < c.coordBits(); i++ {
+			setBit(buf, k, getBit(coord, i))
+			k++
+		}
+	}
+	return buf[size:]
+}
+
+func (c hilbert2Codec[T]) Get(buf []byte) (Point2[T], []byte) {
+	size := c.size()
+	_ = buf[size-1] // check that we have enough
+	cx, cy := hilbertCell(c.bits, topBits(buf, 2*c.bits))
+	x, y := make([]byte, c.width), make([]byte, c.width)
+	putIndexBits(x, cx, c.bits)
+	putIndexBits(y, cy, c.bits)
+	k := 2 * c.bits
+	for _, coord := range [][]byte{x, y} {
+		for i := c.bits; i < c.coordBits(); i++ {
+			setBit(coord, i, getBit(buf, k))
+			k++
+		}
+	}
+	var value Point2[T]
+	value.X, _ = c.coord.Get(x)
+	value.Y, _ = c.coord.Get(y)
+	return value, buf[size:]
+}
+
+func (hilbert2Codec[T]) RequiresTerminator() bool {
+	return false
+}
+
+func (c hilbert2Codec[T]) Describe() Description {
+	return Description{Kind: "hilbert2d", Params: []any{c.bits}, Elem: describeRef(c.coord)}
+}
+
+// A hilbertCellRange is the cell at index along the Hilbert curve of order level.
+// It contains every point whose cell at the full order has an index with the cell's index as a prefix,
+// so all of their encodings are contiguous.
+type hilbertCellRange struct {
+	level int
+	index uint64
+	waste float64
+	exact bool
+}
+
+// scaled returns buf, an encoded coordinate, in units of cells at the full order.
+func (c hilbert2Codec[T]) scaled(buf []byte) float64 {
+	scale := 1.0
+	for range c.coordBits() - c.bits {
+		scale *= 2
+	}
+	return bytesToFloat(buf) / scale
+}
+
+// hilbertQuery is a query rectangle, in encoded coordinates and in units of cells at the full order.
+// It is the coverer of the cells intersecting the rectangle.
+type hilbertQuery struct {
+	lo, hi    [2][]byte
+	loF, hiF  [2]float64
+	bits      int
+	coordBits int
+}
+
+// classify returns whether the cell intersects the query, and if so, how much of its area is outside the query
+// and whether it is entirely within the query.
+func (q hilbertQuery) classify(level int, index uint64) (intersects bool, waste float64, exact bool) {
+	cx, cy := hilbertCell(level, index)
+	cellSize := float64(uint64(1) << (q.bits - level))
+	exact = true
+	overlap := 1.0
+	for d, cell := range [2]uint64{cx, cy} {
+		loTop, hiTop := topBits(q.lo[d], level), topBits(q.hi[d], level)
+		if cell < loTop || cell > hiTop {
+			return false, 0, false
+		}
+		if cell == loTop && !bitsAllEqual(q.lo[d], level, q.coordBits, false) ||
+			cell == hiTop && !bitsAllEqual(q.hi[d], level, q.coordBits, true) {
+			exact = false
+		}
+		cellLo := float64(cell) * cellSize
+		overlap *= min(cellLo+cellSize, q.hiF[d]) - max(cellLo, q.loF[d])
+	}
+	return true, cellSize*cellSize - overlap, exact
+}
+
+// bitsAllEqual returns true if bits [from, to) of buf are all equal to bit.
+func bitsAllEqual(buf []byte, from, to int, bit bool) bool {
+	for i := from; i < to; i++ {
+		if getBit(buf, i) != bit {
+			return false
+		}
+	}
+	return true
+}
+
+// cellBounds returns the first and last indexes of cells at the full order within the cell.
+func (q hilbertQuery) cellBounds(cell hilbertCellRange) (first, last uint64) {
+	shift := 2 * (q.bits - cell.level)
+	first = cell.index << shift
+	return first, first | (1<<shift - 1)
+}
+
+func (hilbertQuery) waste(cell hilbertCellRange) float64 {
+	return cell.waste
+}
+
+func (q hilbertQuery) splittable(cell hilbertCellRange) bool {
+	return !cell.exact && cell.level < q.bits
+}
+
+func (q hilbertQuery) split(cell hilbertCellRange) []hilbertCellRange {
+	var children []hilbertCellRange
+	for j := range uint64(4) { //nolint:mnd
+		index := cell.index<<2 | j
+		if intersects, waste, exact := q.classify(cell.level+1, index); intersects {
+			children = append(children, hilbertCellRange{cell.level + 1, index, waste, exact})
+		}
+	}
+	return children
+}
+
+func (q hilbertQuery) adjacent(a, b hilbertCellRange) bool {
+	_, aLast := q.cellBounds(a)
+	bFirst, _ := q.cellBounds(b)
+	return aLast+1 == bFirst
+}
+
+// ranges returns at most maxRanges key ranges, in order, covering every point in the rectangle between lo and hi.
+//
+// This starts with the single cell containing everything, and repeatedly replaces the cell
+// with the most area outside the rectangle by those of its four sub-cells which intersect the rectangle,
+// unless that would result in more than maxRanges ranges.
+// Cells at the full order are never split, their encodings include all points within them.
+// Adjacent ranges are merged.
+func (c hilbert2Codec[T]) ranges(lo, hi Point2[T], maxRanges int) []KeyRange {
+	q := hilbertQuery{
+		lo:        [2][]byte{c.encodeCoord(lo.X), c.encodeCoord(lo.Y)},
+		hi:        [2][]byte{c.encodeCoord(hi.X), c.encodeCoord(hi.Y)},
+		bits:      c.bits,
+		coordBits: c.coordBits(),
+	}
+	for d := range 2 {
+		if bytes.Compare(q.lo[d], q.hi[d]) > 0 {
+			return nil
+		}
+		q.loF[d], q.hiF[d] = c.scaled(q.lo[d]), c.scaled(q.hi[d])
+	}
+	_, waste, exact := q.classify(0, 0)
+	var ranges []KeyRange
+	begin, last := make([]byte, c.size()), make([]byte, c.size())
+	for _, cell := range coverCells[hilbertCellRange](q, hilbertCellRange{0, 0, waste, exact}, maxRanges) {
+		first, lastIndex := q.cellBounds(cell)
+		clear(begin)
+		putIndexBits(begin, first, 2*c.bits)
+		for i := range last {
+			last[i] = 0xFF
+		}
+		putIndexBits(last, lastIndex, 2*c.bits)
+		ranges = appendRange(ranges, begin, last)
+	}
+	return ranges
+}
+
+// Hilbert2DRanges returns at most maxRanges key ranges, in order, which together contain the encodings of
+// every point in the rectangle with corners lo and hi (inclusive), for a Codec returned by [Hilbert2D].
+// Hilbert2DRanges will panic if codec is not such a Codec.
+//
+// The ranges may also contain encodings of points outside the rectangle,
+// so results of a range scan must still be filtered.
+// More ranges will contain fewer such points, at the cost of more range scans,
+// but cells of the curve are never split, so points in a cell intersecting the rectangle are always included.
+// Hilbert2DRanges returns nil if lo is greater than hi in either dimension.
+func Hilbert2DRanges[T any](codec Codec[Point2[T]], lo, hi Point2[T], maxRanges int) []KeyRange {
+	c, ok := codec.(hilbert2Codec[T])
+	if !ok {
+		panic(badTypeError{codec})
+	}
+	return c.ranges(lo, hi, maxRanges)
+}
@@ -0,0 +1,183 @@
+package lexy_test
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+func TestHilbert2D(t *testing.T) {
+	t.Parallel()
+	codec := lexy.Hilbert2D(lexy.Uint8(), 8)
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.Point2[uint8]]{
+		{"origin", lexy.Point2[uint8]{0, 0}, []byte{0x00, 0x00}},
+		{"(0, 1)", lexy.Point2[uint8]{0, 1}, []byte{0x00, 0x03}},
+		{"(1, 1)", lexy.Point2[uint8]{1, 1}, []byte{0x00, 0x02}},
+		{"(1, 0)", lexy.Point2[uint8]{1, 0}, []byte{0x00, 0x01}},
+		{"quadrant 1", lexy.Point2[uint8]{0, 0x80}, []byte{0x40, 0x00}},
+		{"quadrant 2", lexy.Point2[uint8]{0x80, 0x80}, []byte{0x80, 0x00}},
+		{"quadrant 3", lexy.Point2[uint8]{0xFF, 0}, []byte{0xFF, 0xFF}},
+	})
+	// Each cell is 16x16, points within a cell are ordered by X and then Y.
+	testCodec(t, lexy.Hilbert2D(lexy.Uint8(), 4), []testCase[lexy.Point2[uint8]]{
+		{"origin", lexy.Point2[uint8]{0, 0}, []byte{0x00, 0x00}},
+		{"within first cell", lexy.Point2[uint8]{0x0A, 0x05}, []byte{0x00, 0xA5}},
+		{"quadrant 3", lexy.Point2[uint8]{0xFA, 0x05}, []byte{0xFF, 0xA5}},
+	})
+	testCodec(t, lexy.Hilbert2D(lexy.Int16(), 1), []testCase[lexy.Point2[int16]]{
+		{"origin", lexy.Point2[int16]{0, 0}, []byte{0x80, 0x00, 0x00, 0x00}},
+		{"(-1, -1)", lexy.Point2[int16]{-1, -1}, []byte{0x3F, 0xFF, 0xFF, 0xFF}},
+	})
+}
+
+func TestHilbert2DRoundTrip(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(3, 4))
+	float64Codec := lexy.Hilbert2D(lexy.Float64(), 20)
+	int32Codec := lexy.Hilbert2D(lexy.Int32(), 32)
+	for range 1000 {
+		fp := lexy.Point2[float64]{rng.NormFloat64() * 1e6, rng.NormFloat64()}
+		got, rest := float64Codec.Get(float64Codec.Append(nil, fp))
+		assert.Equal(t, fp, got)
+		assert.Empty(t, rest)
+		ip := lexy.Point2[int32]{rng.Int32() - math.MaxInt32/2, rng.Int32()}
+		gotInt, rest := int32Codec.Get(int32Codec.Append(nil, ip))
+		assert.Equal(t, ip, gotInt)
+		assert.Empty(t, rest)
+	}
+}
+
+// Consecutive points along the curve must be adjacent.
+func TestHilbert2DContinuous(t *testing.T) {
+	t.Parallel()
+	for _, bits := range []int{1, 2, 5, 8} {
+		codec := lexy.Hilbert2D(lexy.Uint8(), bits)
+		side := 1 << bits
+		var keys [][]byte
+		for x := range side {
+			for y := range side {
+				// Only the first point in each cell.
+				p := lexy.Point2[uint8]{uint8(x << (8 - bits)), uint8(y << (8 - bits))}
+				keys = append(keys, codec.Append(nil, p))
+			}
+		}
+		slices.SortFunc(keys, bytes.Compare)
+		for i := 1; i < len(keys); i++ {
+			a, _ := codec.Get(keys[i-1])
+			b, _ := codec.Get(keys[i])
+			dx := math.Abs(float64(a.X) - float64(b.X))
+			dy := math.Abs(float64(a.Y) - float64(b.Y))
+			require.InDelta(t, float64(int(1)<<(8-bits)), dx+dy, 0, "bits %d: %v %v", bits, a, b)
+		}
+	}
+}
+
+func TestHilbert2DRanges(t *testing.T) {
+	t.Parallel()
+	points := allPoint2Uint8()
+	rng := rand.New(rand.NewPCG(5, 6))
+	for _, bits := range []int{4, 8} {
+		codec := lexy.Hilbert2D(lexy.Uint8(), bits)
+		keys := make([][]byte, len(points))
+		for i, p := range points {
+			keys[i] = codec.Append(nil, p)
+		}
+		for range 10 {
+			x1, x2 := uint8(rng.IntN(256)), uint8(rng.IntN(256))
+			y1, y2 := uint8(rng.IntN(256)), uint8(rng.IntN(256))
+			lo := lexy.Point2[uint8]{min(x1, x2), min(y1, y2)}
+			hi := lexy.Point2[uint8]{max(x1, x2), max(y1, y2)}
+			inBox := func(i int) bool {
+				p := points[i]
+				return lo.X <= p.X && p.X <= hi.X && lo.Y <= p.Y && p.Y <= hi.Y
+			}
+			prevExtra := math.MaxInt
+			for _, maxRanges := range []int{1, 4, 16, 64} {
+				ranges := lexy.Hilbert2DRanges(codec, lo, hi, maxRanges)
+				require.NotEmpty(t, ranges)
+				require.LessOrEqual(t, len(ranges), maxRanges)
+				extra := checkRangeKeys(t, ranges, keys, inBox)
+				require.LessOrEqual(t, extra, prevExtra, "more ranges should not cover more points")
+				prevExtra = extra
+			}
+			if bits == 8 {
+				// Cells are single points, so enough ranges are exact.
+				ranges := lexy.Hilbert2DRanges(codec, lo, hi, 10000)
+				require.Zero(t, checkRangeKeys(t, ranges, keys, inBox))
+			}
+		}
+	}
+}
+
+func TestHilbert2DRangesExamples(t *testing.T) {
+	t.Parallel()
+	codec := lexy.Hilbert2D(lexy.Uint8(), 8)
+	// A quadrant is a single exact range.
+	assert.Equal(t, []lexy.KeyRange{{[]byte{0x40, 0x00}, []byte{0x80, 0x00}}},
+		lexy.Hilbert2DRanges(codec, lexy.Point2[uint8]{0, 0x80}, lexy.Point2[uint8]{0x7F, 0xFF}, 10))
+	// Two quadrants adjacent along the curve are also a single range.
+	assert.Equal(t, []lexy.KeyRange{{[]byte{0x40, 0x00}, []byte{0xC0, 0x00}}},
+		lexy.Hilbert2DRanges(codec, lexy.Point2[uint8]{0, 0x80}, lexy.Point2[uint8]{0xFF, 0xFF}, 10))
+	// The whole space is unbounded above.
+	assert.Equal(t, []lexy.KeyRange{{[]byte{0x00, 0x00}, nil}},
+		lexy.Hilbert2DRanges(codec, lexy.Point2[uint8]{0, 0}, lexy.Point2[uint8]{0xFF, 0xFF}, 10))
+	assert.Nil(t, lexy.Hilbert2DRanges(codec, lexy.Point2[uint8]{1, 0}, lexy.Point2[uint8]{0, 0}, 10))
+}
+
+// A long, narrow rectangle is covered by fewer ranges along a Hilbert curve than in Z-order.
+func TestHilbert2DRangesElongated(t *testing.T) {
+	t.Parallel()
+	lo, hi := lexy.Point2[uint16]{1000, 30000}, lexy.Point2[uint16]{9000, 30003}
+	hilbert := lexy.Hilbert2DRanges(lexy.Hilbert2D(lexy.Uint16(), 16), lo, hi, 1_000_000)
+	zOrder := lexy.ZOrder2Ranges(lexy.ZOrder2(lexy.Uint16()), lo, hi, 1_000_000)
+	assert.Less(t, len(hilbert), len(zOrder))
+}
+
+// The number of cells is limited, and each split is incremental,
+// so covering a large rectangle of a fine curve is fast, even with many ranges.
+func TestHilbert2DRangesLarge(t *testing.T) {
+	t.Parallel()
+	codec := lexy.Hilbert2D(lexy.Uint32(), 32)
+	lo, hi := lexy.Point2[uint32]{7, 11}, lexy.Point2[uint32]{1<<31 + 12345, 1<<30 + 99}
+	inBox := func(p lexy.Point2[uint32]) bool {
+		return lo.X <= p.X && p.X <= hi.X && lo.Y <= p.Y && p.Y <= hi.Y
+	}
+	rng := rand.New(rand.NewPCG(7, 8))
+	points := []lexy.Point2[uint32]{lo, hi, {lo.X, hi.Y}, {hi.X, lo.Y}, {6, 11}, {hi.X + 1, hi.Y}}
+	for range 1000 {
+		points = append(points, lexy.Point2[uint32]{rng.Uint32(), rng.Uint32() >> 1})
+	}
+	for _, maxRanges := range []int{1, 8, 64, 1024} {
+		ranges := lexy.Hilbert2DRanges(codec, lo, hi, maxRanges)
+		require.NotEmpty(t, ranges)
+		require.LessOrEqual(t, len(ranges), maxRanges)
+		checkRanges(t, codec, ranges, points, inBox)
+	}
+}
+
+func TestHilbert2DPanics(t *testing.T) {
+	t.Parallel()
+	assert.PanicsWithError(t, "bits must be between 1 and 8, got 0", func() {
+		lexy.Hilbert2D(lexy.Uint8(), 0)
+	})
+	assert.PanicsWithError(t, "bits must be between 1 and 8, got 9", func() {
+		lexy.Hilbert2D(lexy.Uint8(), 9)
+	})
+	assert.PanicsWithError(t, "bits must be between 1 and 32, got 33", func() {
+		lexy.Hilbert2D(lexy.Uint64(), 33)
+	})
+	assert.Panics(t, func() {
+		lexy.Hilbert2D(lexy.String(), 8)
+	})
+	assert.Panics(t, func() {
+		lexy.Hilbert2DRanges(lexy.ZOrder2(lexy.Uint8()), lexy.Point2[uint8]{}, lexy.Point2[uint8]{}, 1)
+	})
+}
@@ -53,6 +53,10 @@ func (boolCodec) RequiresTerminator() bool {
 	return false
 }
 
+func (boolCodec) Describe() Description {
+	return Description{Kind: "bool"}
+}
+
 func (uint8Codec) Append(buf []byte, value uint8) []byte {
 	return append(buf, value)
 }
@@ -70,6 +74,10 @@ func (uint8Codec) RequiresTerminator() bool {
 	return false
 }
 
+func (uint8Codec) Describe() Description {
+	return Description{Kind: "uint8"}
+}
+
 func (uint16Codec) Append(buf []byte, value uint16) []byte {
 	return binary.BigEndian.AppendUint16(buf, value)
 }
@@ -87,6 +95,10 @@ func (uint16Codec) RequiresTerminator() bool {
 	return false
 }
 
+func (uint16Codec) Describe() Description {
+	return Description{Kind: "uint16"}
+}
+
 func (uint32Codec) Append(buf []byte, value uint32) []byte {
 	return binary.BigEndian.AppendUint32(buf, value)
 }
@@ -104,6 +116,10 @@ func (uint32Codec) RequiresTerminator() bool {
 	return false
 }
 
+func (uint32Codec) Describe() Description {
+	return Description{Kind: "uint32"}
+}
+
 func (uint64Codec) Append(buf []byte, value uint64) []byte {
 	return binary.BigEndian.AppendUint64(buf, value)
 }
@@ -121,6 +137,10 @@ func (uint64Codec) RequiresTerminator() bool {
 	return false
 }
 
+func (uint64Codec) Describe() Description {
+	return Description{Kind: "uint64"}
+}
+
 // Codecs for fixed-length signed integral types.
 // These are:
 //   - int8
@@ -160,6 +180,10 @@ func (int8Codec) RequiresTerminator() bool {
 	return false
 }
 
+func (int8Codec) Describe() Description {
+	return Description{Kind: "int8"}
+}
+
 func (int16Codec) Append(buf []byte, value int16) []byte {
 	return binary.BigEndian.AppendUint16(buf, uint16(math.MinInt16^value))
 }
@@ -177,6 +201,10 @@ func (int16Codec) RequiresTerminator() bool {
 	return false
 }
 
+func (int16Codec) Describe() Description {
+	return Description{Kind: "int16"}
+}
+
 func (int32Codec) Append(buf []byte, value int32) []byte {
 	return binary.BigEndian.AppendUint32(buf, uint32(math.MinInt32^value))
 }
@@ -194,6 +222,10 @@ func (int32Codec) RequiresTerminator() bool {
 	return false
 }
 
+func (int32Codec) Describe() Description {
+	return Description{Kind: "int32"}
+}
+
 func (int64Codec) Append(buf []byte, value int64) []byte {
 	return binary.BigEndian.AppendUint64(buf, uint64(math.MinInt64^value))
 }
@@ -210,3 +242,7 @@ func (int64Codec) Get(buf []byte) (int64, []byte) {
 func (int64Codec) RequiresTerminator() bool {
 	return false
 }
+
+func (int64Codec) Describe() Description {
+	return Description{Kind: "int64"}
+}
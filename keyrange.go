@@ -0,0 +1,49 @@
+package lexy
+
+import "bytes"
+
+// KeyRange is a range of encoded keys, including Begin and excluding End.
+// A nil End means the range has no upper bound.
+//
+// KeyRanges are returned by functions which decompose a query into range scans,
+// like [ZOrder2Ranges]. Each KeyRange can be passed directly to a range scan over [Begin, End).
+type KeyRange struct {
+	Begin []byte
+	End   []byte
+}
+
+// Contains returns true if key is within r.
+func (r KeyRange) Contains(key []byte) bool {
+	return bytes.Compare(r.Begin, key) <= 0 && (r.End == nil || bytes.Compare(key, r.End) < 0)
+}
+
+// WithPrefix returns a KeyRange of the keys consisting of prefix followed by a key within r.
+// This is useful when the keys in r are preceded by other encoded data, like a table or tenant identifier.
+func (r KeyRange) WithPrefix(prefix []byte) KeyRange {
+	begin := append(append([]byte{}, prefix...), r.Begin...)
+	if r.End != nil {
+		return KeyRange{begin, append(append([]byte{}, prefix...), r.End...)}
+	}
+	// The smallest key greater than every key with prefix as a prefix, if any.
+	end := append([]byte{}, prefix...)
+	for len(end) > 0 && end[len(end)-1] == 0xFF {
+		end = end[:len(end)-1]
+	}
+	if len(end) == 0 {
+		return KeyRange{begin, nil}
+	}
+	end[len(end)-1]++
+	return KeyRange{begin, end}
+}
+
+// appendRange appends the range [begin, last] to ranges, merging it with the last range if they are adjacent.
+// last is the greatest key in the range, and ranges must be in order with no overlaps.
+// All keys must have the same fixed length. The arguments are not retained.
+func appendRange(ranges []KeyRange, begin, last []byte) []KeyRange {
+	end := successor(append([]byte{}, last...))
+	if n := len(ranges); n > 0 && bytes.Equal(ranges[n-1].End, begin) {
+		ranges[n-1].End = end
+		return ranges
+	}
+	return append(ranges, KeyRange{append([]byte{}, begin...), end})
+}
@@ -0,0 +1,32 @@
+package lexy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/phiryll/lexy"
+)
+
+func TestKeyRangeContains(t *testing.T) {
+	t.Parallel()
+	r := lexy.KeyRange{[]byte{0x10}, []byte{0x20}}
+	assert.False(t, r.Contains([]byte{0x0F, 0xFF}))
+	assert.True(t, r.Contains([]byte{0x10}))
+	assert.True(t, r.Contains([]byte{0x1F, 0xFF}))
+	assert.False(t, r.Contains([]byte{0x20}))
+	unbounded := lexy.KeyRange{[]byte{0x10}, nil}
+	assert.True(t, unbounded.Contains([]byte{0xFF, 0xFF}))
+	assert.False(t, unbounded.Contains([]byte{}))
+}
+
+func TestKeyRangeWithPrefix(t *testing.T) {
+	t.Parallel()
+	r := lexy.KeyRange{[]byte{0x10}, []byte{0x20}}
+	assert.Equal(t, lexy.KeyRange{[]byte{0xAB, 0x10}, []byte{0xAB, 0x20}}, r.WithPrefix([]byte{0xAB}))
+	assert.Equal(t, r, r.WithPrefix(nil))
+	unbounded := lexy.KeyRange{[]byte{0x10}, nil}
+	assert.Equal(t, lexy.KeyRange{[]byte{0xAB, 0x10}, []byte{0xAC}}, unbounded.WithPrefix([]byte{0xAB}))
+	assert.Equal(t, lexy.KeyRange{[]byte{0xAB, 0xFF, 0x10}, []byte{0xAC}}, unbounded.WithPrefix([]byte{0xAB, 0xFF}))
+	assert.Equal(t, lexy.KeyRange{[]byte{0xFF, 0xFF, 0x10}, nil}, unbounded.WithPrefix([]byte{0xFF, 0xFF}))
+}
@@ -0,0 +1,348 @@
+package lexy
+
+import (
+	"math"
+	"slices"
+)
+
+// GeoPoint is a location on the Earth, in degrees of latitude and longitude.
+type GeoPoint struct {
+	Lat, Lng float64
+}
+
+const (
+	maxLat            = 90.0
+	maxLng            = 180.0
+	maxLatLngBits     = 64
+	earthRadiusMeters = 6_371_008.8
+	radiansPerDegree  = math.Pi / maxLng
+)
+
+// DistanceMeters returns the great-circle distance between p and q in meters,
+// using the haversine formula and the mean radius of the Earth.
+func (p GeoPoint) DistanceMeters(q GeoPoint) float64 {
+	lat1, lat2 := p.Lat*radiansPerDegree, q.Lat*radiansPerDegree
+	dLat := lat2 - lat1
+	dLng := (q.Lng - p.Lng) * radiansPerDegree
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(min(1, h)))
+}
+
+// latLngCodec is the Codec for GeoPoints, quantized into cells in the manner of a geohash.
+//
+// Longitude is divided into 2^lngBits equal cells, and latitude into 2^latBits equal cells,
+// where lngBits + latBits is the precision, and lngBits is either equal to latBits or one more.
+// The cell indexes are interleaved from most significant bit to least, longitude first,
+// forming a Z-order index of lngBits + latBits bits.
+// That index is encoded big-endian in as few bytes as possible, left-aligned and padded with 0 bits.
+// Get returns the center of the cell, so this Codec is lossy.
+// Every encoding is the same length, so this Codec does not require escaping.
+type latLngCodec struct {
+	lngBits int
+	latBits int
+}
+
+func newLatLngCodec(precisionBits int) latLngCodec {
+	if precisionBits < 1 || precisionBits > maxLatLngBits {
+		panic(bitsRangeError{precisionBits, maxLatLngBits})
+	}
+	return latLngCodec{(precisionBits + 1) / 2, precisionBits / 2} //nolint:mnd
+}
+
+func (c latLngCodec) precision() int {
+	return c.lngBits + c.latBits
+}
+
+func (c latLngCodec) size() int {
+	return numBytes(c.precision())
+}
+
+// quantize returns the index of the cell containing value, for 2^bits cells covering [-limit, limit].
+func quantize(value, limit float64, bits int) uint64 {
+	f := (value + limit) / (2 * limit) * math.Ldexp(1, bits)
+	cell := uint64(f)
+	if maxCell := uint64(1)<<bits - 1; cell > maxCell {
+		// Only possible for value == limit.
+		return maxCell
+	}
+	return cell
+}
+
+// cellCenter returns the center of the cell at index cell, for 2^bits cells covering [-limit, limit].
+func cellCenter(cell uint64, limit float64, bits int) float64 {
+	return -limit + (float64(cell)+0.5)*(2*limit)/math.Ldexp(1, bits) //nolint:mnd
+}
+
+// cell returns the longitude and latitude cell indexes of value.
+func (c latLngCodec) cell(value GeoPoint) (x, y uint64) {
+	// These comparisons are false for NaNs.
+	if !(math.Abs(value.Lat) <= maxLat && math.Abs(value.Lng) <= maxLng) {
+		panic(geoPointRangeError{value})
+	}
+	return quantize(value.Lng, maxLng, c.lngBits), quantize(value.Lat, maxLat, c.latBits)
+}
+
+func (c latLngCodec) center(x, y uint64) GeoPoint {
+	return GeoPoint{cellCenter(y, maxLat, c.latBits), cellCenter(x, maxLng, c.lngBits)}
+}
+
+// interleave returns the index of the cell whose leading lngPrefixBits and latPrefixBits are x and y,
+// which is a prefix of the full index of every cell within it.
+func interleave(x, y uint64, lngPrefixBits, latPrefixBits int) uint64 {
+	var index uint64
+	for k := range lngPrefixBits + latPrefixBits {
+		index <<= 1
+		if k%2 == 0 {
+			index |= x >> (lngPrefixBits - 1 - k/2) & 1
+		} else {
+			index |= y >> (latPrefixBits - 1 - k/2) & 1
+		}
+	}
+	return index
+}
+
+// deinterleave is the inverse of interleave, for an index of numBits bits.
+func deinterleave(index uint64, numBits int) (x, y uint64) {
+	for k := range numBits {
+		bit := index >> (numBits - 1 - k) & 1
+		if k%2 == 0 {
+			x = x<<1 | bit
+		} else {
+			y = y<<1 | bit
+		}
+	}
+	return x, y
+}
+
+func (c latLngCodec) Append(buf []byte, value GeoPoint) []byte {
+	buf = append(buf, make([]byte, c.size())...)
+	c.Put(buf[len(buf)-c.size():], value)
+	return buf
+}
+
+func (c latLngCodec) Put(buf []byte, value GeoPoint) []byte {
+	size := c.size()
+	_ = buf[size-1] // check that we have room
+	x, y := c.cell(value)
+	clear(buf[:size])
+	putIndexBits(buf, interleave(x, y, c.lngBits, c.latBits), c.precision())
+	return buf[size:]
+}
+
+func (c latLngCodec) Get(buf []byte) (GeoPoint, []byte) {
+	size := c.size()
+	_ = buf[size-1] // check that we have enough
+	x, y := deinterleave(topBits(buf, c.precision()), c.precision())
+	return c.center(x, y), buf[size:]
+}
+
+func (latLngCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (c latLngCodec) Describe() Description {
+	return Description{Kind: "latlng", Params: []any{c.lngBits + c.latBits}}
+}
+
+// neighbors returns the centers of the cells adjacent to the cell containing value, including diagonally,
+// in encoded order. Longitude wraps around at ±180 degrees, but latitude does not wrap at the poles.
+func (c latLngCodec) neighbors(value GeoPoint) []GeoPoint {
+	x, y := c.cell(value)
+	numX, numY := int64(1)<<c.lngBits, int64(1)<<c.latBits
+	var indexes []uint64
+	for dy := int64(-1); dy <= 1; dy++ {
+		ny := int64(y) + dy
+		if ny < 0 || ny >= numY {
+			continue
+		}
+		for dx := int64(-1); dx <= 1; dx++ {
+			nx := (int64(x) + dx + numX) % numX
+			if nx == int64(x) && ny == int64(y) {
+				continue
+			}
+			index := interleave(uint64(nx), uint64(ny), c.lngBits, c.latBits)
+			if !slices.Contains(indexes, index) {
+				indexes = append(indexes, index)
+			}
+		}
+	}
+	slices.Sort(indexes)
+	neighbors := make([]GeoPoint, len(indexes))
+	for i, index := range indexes {
+		neighbors[i] = c.center(deinterleave(index, c.precision()))
+	}
+	return neighbors
+}
+
+// A latLngBox is a rectangle of cells, with longitude cell indexes in [x0, x1] and latitude cell indexes in [y0, y1].
+type latLngBox struct {
+	x0, x1, y0, y1 uint64
+}
+
+// A latLngCell is a cell at the given level, containing every cell whose index has prefix as its leading level bits.
+// Its encodings are contiguous.
+type latLngCell struct {
+	level  int
+	prefix uint64
+	waste  float64
+	exact  bool
+}
+
+// bounds returns the rectangle of cells at the full precision which are within cell.
+func (c latLngCodec) bounds(cell latLngCell) latLngBox {
+	x, y := deinterleave(cell.prefix, cell.level)
+	lngShift, latShift := c.lngBits-(cell.level+1)/2, c.latBits-cell.level/2 //nolint:mnd
+	return latLngBox{
+		x << lngShift, (x+1)<<lngShift - 1,
+		y << latShift, (y+1)<<latShift - 1,
+	}
+}
+
+// indexBounds returns the first and last full indexes of cells within cell.
+func (c latLngCodec) indexBounds(cell latLngCell) (first, last uint64) {
+	shift := c.precision() - cell.level
+	first = cell.prefix << shift
+	return first, first | (uint64(1)<<shift - 1)
+}
+
+// newCell returns the cell, whether it intersects any of the boxes, which must not overlap.
+func (c latLngCodec) newCell(level int, prefix uint64, boxes []latLngBox) (latLngCell, bool) {
+	cell := latLngCell{level: level, prefix: prefix}
+	b := c.bounds(cell)
+	area := (float64(b.x1-b.x0) + 1) * (float64(b.y1-b.y0) + 1)
+	overlap := 0.0
+	for _, box := range boxes {
+		x0, x1 := max(b.x0, box.x0), min(b.x1, box.x1)
+		y0, y1 := max(b.y0, box.y0), min(b.y1, box.y1)
+		if x0 <= x1 && y0 <= y1 {
+			overlap += (float64(x1-x0) + 1) * (float64(y1-y0) + 1)
+		}
+	}
+	cell.waste = area - overlap
+	cell.exact = cell.waste == 0
+	return cell, overlap > 0
+}
+
+// latLngCover is the coverer of the cells intersecting boxes, which must not overlap.
+type latLngCover struct {
+	codec latLngCodec
+	boxes []latLngBox
+}
+
+func (latLngCover) waste(cell latLngCell) float64 {
+	return cell.waste
+}
+
+func (cv latLngCover) splittable(cell latLngCell) bool {
+	return !cell.exact && cell.level < cv.codec.precision()
+}
+
+func (cv latLngCover) split(cell latLngCell) []latLngCell {
+	var children []latLngCell
+	for bit := range uint64(2) { //nolint:mnd
+		if child, ok := cv.codec.newCell(cell.level+1, cell.prefix<<1|bit, cv.boxes); ok {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+func (cv latLngCover) adjacent(a, b latLngCell) bool {
+	_, aLast := cv.codec.indexBounds(a)
+	bFirst, _ := cv.codec.indexBounds(b)
+	return aLast+1 == bFirst
+}
+
+// ranges returns at most maxRanges key ranges, in order, covering every cell in boxes, which must not overlap.
+//
+// This starts with the single cell containing everything, and repeatedly replaces the cell
+// with the most area outside the boxes by those of its two halves which intersect the boxes,
+// unless that would result in more than maxRanges ranges.
+// Adjacent ranges are merged.
+func (c latLngCodec) ranges(boxes []latLngBox, maxRanges int) []KeyRange {
+	root, _ := c.newCell(0, 0, boxes)
+	var ranges []KeyRange
+	begin, last := make([]byte, c.size()), make([]byte, c.size())
+	for _, cell := range coverCells[latLngCell](latLngCover{c, boxes}, root, maxRanges) {
+		first, lastIndex := c.indexBounds(cell)
+		clear(begin)
+		putIndexBits(begin, first, c.precision())
+		for i := range last {
+			last[i] = 0xFF
+		}
+		putIndexBits(last, lastIndex, c.precision())
+		ranges = appendRange(ranges, begin, last)
+	}
+	return ranges
+}
+
+// radiusBoxes returns the boxes of cells covering the bounding box of the circle of radius meters around center.
+// There are two boxes if the circle crosses the antimeridian.
+func (c latLngCodec) radiusBoxes(center GeoPoint, radius float64) []latLngBox {
+	angle := radius / earthRadiusMeters
+	degrees := angle / radiansPerDegree
+	latLo, latHi := center.Lat-degrees, center.Lat+degrees
+	lngLo, lngHi := -maxLng, maxLng
+	if latLo > -maxLat && latHi < maxLat {
+		// The circle does not contain a pole, see http://janmatuschek.de/LatitudeLongitudeBoundingCoordinates.
+		dLng := math.Asin(math.Sin(angle)/math.Cos(center.Lat*radiansPerDegree)) / radiansPerDegree
+		lngLo, lngHi = center.Lng-dLng, center.Lng+dLng
+	}
+	latLo, latHi = max(latLo, -maxLat), min(latHi, maxLat)
+	y0, y1 := quantize(latLo, maxLat, c.latBits), quantize(latHi, maxLat, c.latBits)
+	box := func(lo, hi float64) latLngBox {
+		return latLngBox{quantize(lo, maxLng, c.lngBits), quantize(hi, maxLng, c.lngBits), y0, y1}
+	}
+	var lower, upper latLngBox
+	switch {
+	case lngLo < -maxLng:
+		lower, upper = box(-maxLng, lngHi), box(lngLo+2*maxLng, maxLng)
+	case lngHi > maxLng:
+		lower, upper = box(-maxLng, lngHi-2*maxLng), box(lngLo, maxLng)
+	default:
+		return []latLngBox{box(lngLo, lngHi)}
+	}
+	if upper.x0 <= lower.x1 {
+		// The boxes overlap after quantization, so together they cover every longitude.
+		return []latLngBox{box(-maxLng, maxLng)}
+	}
+	return []latLngBox{lower, upper}
+}
+
+// LatLngNeighbors returns the centers of the cells adjacent to the cell containing point, including diagonally,
+// in encoded order, for a Codec returned by [LatLng]. LatLngNeighbors will panic if codec is not such a Codec,
+// or if point is not a valid location.
+//
+// Longitude wraps around at ±180 degrees, but latitude does not wrap at the poles,
+// so there are usually 8 neighbors, and fewer near the poles or if the cells are very large.
+// Scanning the cell containing a point and its neighbors finds every point
+// within the size of a cell of it, as the prefix of a geohash does.
+func LatLngNeighbors(codec Codec[GeoPoint], point GeoPoint) []GeoPoint {
+	c, ok := codec.(latLngCodec)
+	if !ok {
+		panic(badTypeError{codec})
+	}
+	return c.neighbors(point)
+}
+
+// LatLngRadiusRanges returns at most maxRanges key ranges, in order, which together contain the encodings of
+// every point within radius meters of center, for a Codec returned by [LatLng].
+// LatLngRadiusRanges will panic if codec is not such a Codec, or if center is not a valid location.
+//
+// The ranges cover the cells intersecting the bounding box of the circle, wrapping around the antimeridian,
+// and may also contain encodings of points outside the circle, so results of a range scan must still be filtered,
+// for example with [GeoPoint.DistanceMeters].
+// More ranges will contain fewer such points, at the cost of more range scans.
+// Cells are never split, so points in a cell intersecting the bounding box are always included.
+// LatLngRadiusRanges returns nil if radius is negative or NaN.
+func LatLngRadiusRanges(codec Codec[GeoPoint], center GeoPoint, radius float64, maxRanges int) []KeyRange {
+	c, ok := codec.(latLngCodec)
+	if !ok {
+		panic(badTypeError{codec})
+	}
+	c.cell(center) // check that center is valid
+	if !(radius >= 0) {
+		return nil
+	}
+	return c.ranges(c.radiusBoxes(center, radius), maxRanges)
+}
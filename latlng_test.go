@@ -0,0 +1,215 @@
+package lexy_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+func TestLatLng(t *testing.T) {
+	t.Parallel()
+	codec := lexy.LatLng(2)
+	assert.False(t, codec.RequiresTerminator())
+	// Values are cell centers, since this Codec is lossy.
+	testCodec(t, codec, []testCase[lexy.GeoPoint]{
+		{"south west", lexy.GeoPoint{-45, -90}, []byte{0x00}},
+		{"north west", lexy.GeoPoint{45, -90}, []byte{0x40}},
+		{"south east", lexy.GeoPoint{-45, 90}, []byte{0x80}},
+		{"north east", lexy.GeoPoint{45, 90}, []byte{0xC0}},
+	})
+	testCodec(t, lexy.LatLng(16), []testCase[lexy.GeoPoint]{
+		{"south east corner", lexy.GeoPoint{-89.6484375, 179.296875}, []byte{0xAA, 0xAA}},
+		{"north west corner", lexy.GeoPoint{89.6484375, -179.296875}, []byte{0x55, 0x55}},
+	})
+	// Longitude gets the extra bit, followed by 7 bits of padding.
+	testCodec(t, lexy.LatLng(9), []testCase[lexy.GeoPoint]{
+		{"north east corner", lexy.GeoPoint{84.375, 174.375}, []byte{0xFF, 0x80}},
+		{"south west corner", lexy.GeoPoint{-84.375, -174.375}, []byte{0x00, 0x00}},
+	})
+}
+
+func TestLatLngLossy(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(7, 8))
+	codec := lexy.LatLng(52)
+	for range 1000 {
+		p := lexy.GeoPoint{rng.Float64()*180 - 90, rng.Float64()*360 - 180}
+		buf := codec.Append(nil, p)
+		got, rest := codec.Get(buf)
+		assert.Empty(t, rest)
+		assert.Less(t, p.DistanceMeters(got), 1.0)
+		assert.Equal(t, buf, codec.Append(nil, got), "the cell center must be in the same cell")
+	}
+	assert.Equal(t, []byte{0x00}, lexy.LatLng(8).Append(nil, lexy.GeoPoint{-90, -180}))
+	assert.Equal(t, []byte{0xFF}, lexy.LatLng(8).Append(nil, lexy.GeoPoint{90, 180}))
+	assert.Equal(t, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+		lexy.LatLng(64).Append(nil, lexy.GeoPoint{90, 180}))
+}
+
+func TestLatLngOrdering(t *testing.T) {
+	t.Parallel()
+	// Longitude is the most significant bit.
+	testOrdering(t, lexy.LatLng(20), []testCase[lexy.GeoPoint]{
+		{"south west", lexy.GeoPoint{-80, -170}, nil},
+		{"north west", lexy.GeoPoint{80, -170}, nil},
+		{"south east", lexy.GeoPoint{-80, 170}, nil},
+		{"north east", lexy.GeoPoint{80, 170}, nil},
+	})
+}
+
+func TestDistanceMeters(t *testing.T) {
+	t.Parallel()
+	london := lexy.GeoPoint{51.5074, -0.1278}
+	paris := lexy.GeoPoint{48.8566, 2.3522}
+	assert.InDelta(t, 343_500, london.DistanceMeters(paris), 1000)
+	assert.InDelta(t, 0, london.DistanceMeters(london), 1e-9)
+	assert.InDelta(t, math.Pi*6_371_008.8, lexy.GeoPoint{0, 0}.DistanceMeters(lexy.GeoPoint{0, 180}), 1e-6)
+}
+
+func TestLatLngNeighbors(t *testing.T) {
+	t.Parallel()
+	codec := lexy.LatLng(16)
+	cellLng, cellLat := 360.0/256, 180.0/256
+	checkNeighbors := func(p lexy.GeoPoint, wantCount int) {
+		neighbors := lexy.LatLngNeighbors(codec, p)
+		require.Len(t, neighbors, wantCount)
+		center, _ := codec.Get(codec.Append(nil, p))
+		var prev []byte
+		for _, n := range neighbors {
+			dLng := math.Abs(n.Lng - center.Lng)
+			dLng = math.Min(dLng, 360-dLng)
+			assert.InDelta(t, 0, math.Round(dLng/cellLng)*cellLng-dLng, 1e-9)
+			assert.LessOrEqual(t, math.Round(dLng/cellLng), 1.0)
+			assert.LessOrEqual(t, math.Round(math.Abs(n.Lat-center.Lat)/cellLat), 1.0)
+			assert.NotEqual(t, center, n)
+			key := codec.Append(nil, n)
+			assert.Less(t, prev, key)
+			prev = key
+		}
+	}
+	checkNeighbors(lexy.GeoPoint{0, 0}, 8)
+	checkNeighbors(lexy.GeoPoint{-33.9, 151.2}, 8)
+	checkNeighbors(lexy.GeoPoint{89.9, 10}, 5)
+	checkNeighbors(lexy.GeoPoint{-90, -180}, 5)
+	checkNeighbors(lexy.GeoPoint{12, 179.9}, 8)
+
+	assert.Contains(t, lexy.LatLngNeighbors(codec, lexy.GeoPoint{0.1, 179.9}),
+		lexy.GeoPoint{cellLat / 2, -180 + cellLng/2})
+	assert.Equal(t, []lexy.GeoPoint{{45, -90}, {-45, 90}, {45, 90}},
+		lexy.LatLngNeighbors(lexy.LatLng(2), lexy.GeoPoint{-45, -90}))
+	assert.Equal(t, []lexy.GeoPoint{{0, 90}}, lexy.LatLngNeighbors(lexy.LatLng(1), lexy.GeoPoint{-10, -10}))
+}
+
+func TestLatLngRadiusRanges(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(9, 10))
+	codec := lexy.LatLng(24)
+	for _, tt := range []struct {
+		name   string
+		center lexy.GeoPoint
+		radius float64
+	}{
+		{"city", lexy.GeoPoint{40.7, -74}, 30_000},
+		{"zero radius", lexy.GeoPoint{-12.3, 45.6}, 0},
+		{"antimeridian", lexy.GeoPoint{-17.7, 179.5}, 200_000},
+		{"north pole", lexy.GeoPoint{88.5, 20}, 300_000},
+		{"south pole", lexy.GeoPoint{-90, 0}, 1_000_000},
+		{"large", lexy.GeoPoint{10, 100}, 5_000_000},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Random points near the center, and the center itself.
+			spread := 2*tt.radius/111_000 + 0.01
+			points := []lexy.GeoPoint{tt.center}
+			for range 20000 {
+				lat := math.Max(-90, math.Min(90, tt.center.Lat+(rng.Float64()*2-1)*spread))
+				lng := math.Mod(tt.center.Lng+(rng.Float64()*2-1)*spread+540, 360) - 180
+				points = append(points, lexy.GeoPoint{lat, lng})
+			}
+			keys := make([][]byte, len(points))
+			for i, p := range points {
+				keys[i] = codec.Append(nil, p)
+			}
+			inCircle := func(i int) bool {
+				return tt.center.DistanceMeters(points[i]) <= tt.radius
+			}
+			for _, maxRanges := range []int{1, 4, 16} {
+				ranges := lexy.LatLngRadiusRanges(codec, tt.center, tt.radius, maxRanges)
+				require.NotEmpty(t, ranges)
+				require.LessOrEqual(t, len(ranges), maxRanges)
+				checkRangeKeys(t, ranges, keys, inCircle)
+			}
+		})
+	}
+}
+
+// Each split is incremental, so covering a circle at a fine precision is fast, even with many ranges.
+func TestLatLngRadiusRangesLarge(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewPCG(11, 12))
+	codec := lexy.LatLng(52)
+	center, radius := lexy.GeoPoint{40.7, -74}, 50_000.0
+	points := []lexy.GeoPoint{center}
+	for range 2000 {
+		points = append(points, lexy.GeoPoint{center.Lat + rng.Float64() - 0.5, center.Lng + rng.Float64() - 0.5})
+	}
+	keys := make([][]byte, len(points))
+	for i, p := range points {
+		keys[i] = codec.Append(nil, p)
+	}
+	inCircle := func(i int) bool {
+		return center.DistanceMeters(points[i]) <= radius
+	}
+	for _, maxRanges := range []int{16, 256, 1024} {
+		ranges := lexy.LatLngRadiusRanges(codec, center, radius, maxRanges)
+		require.NotEmpty(t, ranges)
+		require.LessOrEqual(t, len(ranges), maxRanges)
+		checkRangeKeys(t, ranges, keys, inCircle)
+	}
+}
+
+func TestLatLngRadiusRangesExamples(t *testing.T) {
+	t.Parallel()
+	codec := lexy.LatLng(16)
+	// The whole world is unbounded above.
+	assert.Equal(t, []lexy.KeyRange{{[]byte{0x00, 0x00}, nil}},
+		lexy.LatLngRadiusRanges(codec, lexy.GeoPoint{0, 0}, 30_000_000, 10))
+	// A single cell.
+	assert.Equal(t, []lexy.KeyRange{{[]byte{0xC0, 0x00}, []byte{0xC0, 0x01}}},
+		lexy.LatLngRadiusRanges(codec, lexy.GeoPoint{0.1, 0.1}, 0, 10))
+	assert.Nil(t, lexy.LatLngRadiusRanges(codec, lexy.GeoPoint{0, 0}, -1, 10))
+	assert.Nil(t, lexy.LatLngRadiusRanges(codec, lexy.GeoPoint{0, 0}, math.NaN(), 10))
+}
+
+func TestLatLngPanics(t *testing.T) {
+	t.Parallel()
+	assert.PanicsWithError(t, "bits must be between 1 and 64, got 0", func() {
+		lexy.LatLng(0)
+	})
+	assert.PanicsWithError(t, "bits must be between 1 and 64, got 65", func() {
+		lexy.LatLng(65)
+	})
+	codec := lexy.LatLng(32)
+	for _, p := range []lexy.GeoPoint{{91, 0}, {0, -180.5}, {math.NaN(), 0}, {0, math.Inf(1)}} {
+		assert.Panics(t, func() {
+			codec.Append(nil, p)
+		})
+		assert.Panics(t, func() {
+			lexy.LatLngNeighbors(codec, p)
+		})
+		assert.Panics(t, func() {
+			lexy.LatLngRadiusRanges(codec, p, 10, 10)
+		})
+	}
+	assert.Panics(t, func() {
+		lexy.LatLngNeighbors(lexy.Negate(lexy.LatLng(8)), lexy.GeoPoint{})
+	})
+	assert.Panics(t, func() {
+		lexy.LatLngRadiusRanges(lexy.Negate(lexy.LatLng(8)), lexy.GeoPoint{}, 1, 1)
+	})
+}
@@ -14,9 +14,9 @@ These latter functions are only needed when creating a Codec for a type that is
 
 All Codecs provided by lexy are safe for concurrent use if their delegate Codecs (if any) are.
 
-All Codecs provided by lexy will order nils first if nil can be encoded.
+All Codecs provided by lexy will order nils first if nil can be encoded, treating absent [Optional] values as nil.
 Invoking [NilsLast](codec) on a Codec will return a Codec which orders nils last,
-but only for the pointer, slice, map, []byte, and *big.Int/Float/Rat Codecs provided by lexy.
+but only for the pointer, [Optional], slice, map, []byte, and *big.Int/Float/Rat Codecs provided by lexy.
 
 See [Codec.RequiresTerminator] for details on when escaping and terminating encoded bytes is required.
 
@@ -26,11 +26,22 @@ These Codec-returning functions do not require specifying a type parameter when
   - [Int], [Int8], [Int16], [Int32], [Int64]
   - [Float32], [Float64]
   - [Complex64], [Complex128]
-  - [String], [TerminatedString]
-  - [Time], [Duration]
+  - [String], [TerminatedString], [NaturalString], [FoldedString], [CollatedString]
+  - [DomainName], [PathSegments]
+  - [Time], [TimeUTC], [TimeSeconds], [TimeMillis], [TimeMicros], [TimeZoned], [Duration]
+  - [TimeDesc], [TimeUTCDesc], [TimeSecondsDesc], [TimeMillisDesc], [TimeMicrosDesc], [DurationDesc]
+  - [CivilDate], [CivilTimeOfDay], [CivilDateTime]
+  - [SemVer]
   - [BigInt], [BigFloat], [BigRat]
   - [Bytes], [TerminatedBytes]
+  - [BitSet], [FixedBitSet]
   - [PointerTo], [SliceOf], [MapOf]
+  - [OptionalOf]
+  - [ShortLexString], [ShortLexBytes], [ShortLexSliceOf]
+  - [OneOf]
+  - [ZOrder2], [ZOrder3], [Hilbert2D]
+  - [LatLng]
+  - [Enum], [EnumWithFallback]
   - [Negate]
   - [Terminate]
   - [NilsLast]
@@ -45,6 +56,22 @@ These Codec-returning functions require specifying a type parameter when invoked
   - [CastBytes]
   - [CastPointerTo], [CastSliceOf], [CastMapOf]
 
+[NewerThan] and [NotOlderThan] build range scan bounds for the descending time.Time Codecs.
+
+[VariantOf] creates a [Variant] of an interface type for [OneOf].
+
+[ParseSchema] creates a Codec from a textual description, like "tuple(uint32, desc(time), terminated(string))".
+
+[Describe] returns a structured [Description] of a Codec's encoding, provided by Codecs implementing [Describer].
+All Codecs provided by lexy implement Describer.
+
+[Format] and [FormatLenient] write a human-readable form of an encoded value, for debugging,
+and [FormatPartial] also returns a [DecodeError] locating the part which could not be decoded.
+
+[ZOrder2Ranges] and [ZOrder3Ranges] decompose a query box into [KeyRange] scans for the Z-order Codecs,
+and [Hilbert2DRanges] does the same for the Hilbert curve Codecs.
+[LatLngRadiusRanges] and [LatLngNeighbors] support proximity scans for the [LatLng] Codec.
+
 These are implementations of [Prefix], used when creating user-defined Codecs
 that can encode types whose instances can be nil.
   - [PrefixNilsFirst], [PrefixNilsLast]
@@ -66,7 +93,7 @@ import (
 //
 // All Codecs provided by lexy will order nils first if instances of type T can be nil.
 // Invoking [NilsLast](codec) on a Codec will return a Codec which orders nils last,
-// but only for the pointer, slice, map, []byte, and *big.Int/Float/Rat Codecs provided by lexy.
+// but only for the pointer, [Optional], slice, map, []byte, and *big.Int/Float/Rat Codecs provided by lexy.
 //
 // If instances of type T can be nil,
 // implementations should invoke the appropriate method of [PrefixNilsFirst] or [PrefixNilsLast]
@@ -134,12 +161,33 @@ var (
 	stdString     = stringCodec{}
 	stdDuration   = castInt64[time.Duration]{}
 	stdTime       = timeCodec{}
+	stdTimeUTC    = timeUTCCodec{}
+	stdTimeSec    = truncTimeCodec{1}
+	stdTimeMilli  = truncTimeCodec{1_000}
+	stdTimeMicro  = truncTimeCodec{1_000_000}
+	stdTimeZoned  = zonedTimeCodec{}
+	stdDate       = dateCodec{}
+	stdTimeOfDay  = timeOfDayCodec{}
+	stdDateTime   = dateTimeCodec{}
+	stdTimeDesc   = descTimeCodec{negateCodec[time.Time]{stdTime}, 12}
+	stdUTCDesc    = descTimeCodec{negateCodec[time.Time]{stdTimeUTC}, 12}
+	stdSecDesc    = descTimeCodec{negateCodec[time.Time]{stdTimeSec}, 8}
+	stdMilliDesc  = descTimeCodec{negateCodec[time.Time]{stdTimeMilli}, 8}
+	stdMicroDesc  = descTimeCodec{negateCodec[time.Time]{stdTimeMicro}, 8}
+	stdDurDesc    = negateCodec[time.Duration]{stdDuration}
+	stdSLString   = shortLexStringCodec{}
+	stdSLBytes    = shortLexBytesCodec{PrefixNilsFirst}
 	stdBigFloat   = bigFloatCodec{PrefixNilsFirst}
 	stdBigInt     = bigIntCodec{PrefixNilsFirst}
 	stdBigRat     = bigRatCodec{PrefixNilsFirst}
 	stdBytes      = bytesCodec{PrefixNilsFirst}
 	stdTermString = terminatorCodec[string]{stdString}
 	stdTermBytes  = terminatorCodec[[]byte]{stdBytes}
+	stdNatural    = terminatorCodec[string]{naturalCodec{}}
+	stdFolded     = collatedCodec{caseFolder{}}
+	stdDomain     = splitCodec{".", true}
+	stdBitSet     = bitSetCodec{PrefixNilsFirst}
+	stdSemVer     = semVerCodec{}
 )
 
 // Empty returns a Codec that encodes instances of T to zero bytes.
@@ -247,6 +295,80 @@ func String() Codec[string] { return stdString }
 // This is a convenience function, it returns the same Codec as [Terminate]([String]()).
 func TerminatedString() Codec[string] { return stdTermString }
 
+// NaturalString returns a Codec for the string type in natural order,
+// where runs of ASCII digits are ordered by their numeric values.
+// For example, "file2" < "file10" < "file10a" < "file11".
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// Digit runs of any length are supported. Numerically equal runs with fewer leading zeros are ordered first,
+// and that difference takes precedence over anything that follows. For example, "a1" < "a1b" < "a01" < "a001".
+// Non-digit bytes are ordered as they are by [String].
+// Get returns exactly the original string.
+//
+// Like [TerminatedString], this Codec escapes and terminates its encodings,
+// so it can be safely embedded in other encodings.
+func NaturalString() Codec[string] { return stdNatural }
+
+// FoldedString returns a Codec for the string type in case-insensitive order.
+// Strings are ordered by their case-folded forms first, and then as they are by [String].
+// For example, "a" < "B" < "b" < "c", and "GO" < "Go" < "go" < "Go!".
+// This Codec requires escaping, as defined by [Codec.RequiresTerminator].
+//
+// A string is encoded as its case-folded form, escaped and terminated, followed by the original string.
+// Get returns exactly the original string.
+// Case folding replaces every rune r with unicode.ToLower(unicode.ToUpper(r)),
+// a close approximation of Unicode simple case folding.
+// Like [String], this is not locale-aware collation, see [CollatedString] for that.
+func FoldedString() Codec[string] { return stdFolded }
+
+// CollatedString returns a Codec for the string type in the order defined by collator.
+// Strings are ordered by their collation keys first, and then as they are by [String].
+// This Codec requires escaping, as defined by [Codec.RequiresTerminator].
+//
+// A string is encoded as its collation key, escaped and terminated, followed by the original string.
+// Get returns exactly the original string, and does not use collator.
+// Changing collator, including upgrading the collation data it uses, may change the order of encodings.
+// Encodings stored with a different collator will still decode correctly, but may be out of order.
+// CollatedString will panic if collator is nil.
+func CollatedString(collator Collator) Codec[string] {
+	if collator == nil {
+		panic(errNilCollator)
+	}
+	return collatedCodec{collator}
+}
+
+// DomainName returns a Codec for hostnames and other dot-separated names, ordered by reversed labels.
+// For example, "www.example.com" is ordered as if it were the labels {"com", "example", "www"},
+// so all subdomains of a domain are adjacent and follow it.
+// This Codec requires escaping, as defined by [Codec.RequiresTerminator].
+//
+// The empty string is encoded as zero bytes.
+// Any other string is encoded as its labels in reverse order, each one escaped and terminated.
+// Each label is ordered as it is by [String], before any longer label it is a prefix of,
+// so "example.com" and its subdomains are ordered before "example-a.com".
+// The encoding of "example.com" is a prefix of the encoding of every one of its subdomains,
+// so it can be used directly as the prefix in a prefix scan.
+// Labels are encoded as-is, so callers should normalize case if needed.
+func DomainName() Codec[string] { return stdDomain }
+
+// PathSegments returns a Codec for strings separated into segments by sep, ordered segment by segment.
+// For example, with sep "/", "/a/b" < "/a/c" < "/a-b", which is not true for [String].
+// PathSegments will panic if sep is empty.
+// This Codec requires escaping, as defined by [Codec.RequiresTerminator].
+//
+// The empty string is encoded as zero bytes.
+// Any other string is encoded as its segments in order, each one escaped and terminated.
+// Each segment is ordered as it is by [String], before any longer segment it is a prefix of.
+// The encoding of "/a" is a prefix of the encoding of every path beginning with "/a/",
+// so it can be used directly as the prefix in a prefix scan.
+// Note that "/a" and "/a/" are distinct, the latter has a final empty segment.
+func PathSegments(sep string) Codec[string] {
+	if sep == "" {
+		panic(errEmptySeparator)
+	}
+	return splitCodec{sep, false}
+}
+
 // Time returns a Codec for the time.Time type.
 // The encoded order is UTC time first, timezone offset second.
 // This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
@@ -257,10 +379,131 @@ func TerminatedString() Codec[string] { return stdTermString }
 // and [time.Time.Zone] can return names that will fail with [time.LoadLocation] in the same program.
 func Time() Codec[time.Time] { return stdTime }
 
+// TimeUTC returns a Codec for the time.Time type which ignores the timezone.
+// The encoded order is UTC time.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// This Codec is lossy. It does not encode the timezone, and Get always returns a time.Time in UTC.
+// Its encodings are 12 bytes long, compared to 16 bytes for [Time].
+func TimeUTC() Codec[time.Time] { return stdTimeUTC }
+
+// TimeSeconds returns a Codec for the time.Time type which truncates to whole seconds and ignores the timezone.
+// The encoded order is UTC time.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// This Codec is lossy. It does not encode the timezone, and Get always returns a time.Time in UTC.
+// Values are truncated towards the past to whole seconds, which preserves their order.
+// Its encodings are 8 bytes long, and can represent any time.Time.
+func TimeSeconds() Codec[time.Time] { return stdTimeSec }
+
+// TimeMillis returns a Codec for the time.Time type which truncates to whole milliseconds
+// and ignores the timezone.
+// Other than the precision, this is the same as [TimeSeconds].
+//
+// Its encodings are 8 bytes long, and can represent times within about 292 million years of 1970.
+// The encoding of a time.Time outside that range is undefined.
+func TimeMillis() Codec[time.Time] { return stdTimeMilli }
+
+// TimeMicros returns a Codec for the time.Time type which truncates to whole microseconds
+// and ignores the timezone.
+// Other than the precision, this is the same as [TimeSeconds].
+//
+// Its encodings are 8 bytes long, and can represent times within about 292 thousand years of 1970.
+// The encoding of a time.Time outside that range is undefined.
+func TimeMicros() Codec[time.Time] { return stdTimeMicro }
+
+// TimeZoned returns a Codec for the time.Time type which also encodes the name of its location.
+// The encoded order is UTC time first, timezone offset second, and location name third.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// A value is encoded as it is by [Time], followed by the escaped and terminated name of [time.Time.Location].
+// Get restores the location with [time.LoadLocation] if the loaded location has the encoded offset
+// at the encoded instant, and otherwise uses a [time.FixedZone] with the encoded name and offset.
+// Round trips are lossless for time.UTC, time.Local, and locations loaded by name,
+// as long as the tz database used by Get agrees with the one used when encoding.
+func TimeZoned() Codec[time.Time] { return stdTimeZoned }
+
+// TimeDesc returns a Codec for the time.Time type which orders newer instants first.
+// The encoded order is UTC time first (newest first), timezone offset second (largest first).
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// An encoding is the bitwise complement of the encoding produced by [Time],
+// so TimeDesc behaves the same as [Negate]([Time]()) except for being usable with [NewerThan] and [NotOlderThan].
+// The encoded instant is the first 12 bytes, and the encoded offset is the last 4 bytes.
+// This Codec is lossy in the same way as [Time].
+func TimeDesc() Codec[time.Time] { return stdTimeDesc }
+
+// TimeUTCDesc returns a Codec for the time.Time type which orders newer instants first and ignores the timezone.
+// An encoding is the bitwise complement of the encoding produced by [TimeUTC].
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+// This Codec is lossy in the same way as [TimeUTC].
+func TimeUTCDesc() Codec[time.Time] { return stdUTCDesc }
+
+// TimeSecondsDesc returns a Codec for the time.Time type which orders newer instants first,
+// truncates to whole seconds, and ignores the timezone.
+// An encoding is the bitwise complement of the encoding produced by [TimeSeconds].
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+// This Codec is lossy in the same way as [TimeSeconds].
+func TimeSecondsDesc() Codec[time.Time] { return stdSecDesc }
+
+// TimeMillisDesc returns a Codec for the time.Time type which orders newer instants first,
+// truncates to whole milliseconds, and ignores the timezone.
+// An encoding is the bitwise complement of the encoding produced by [TimeMillis].
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+// This Codec is lossy in the same way as [TimeMillis].
+func TimeMillisDesc() Codec[time.Time] { return stdMilliDesc }
+
+// TimeMicrosDesc returns a Codec for the time.Time type which orders newer instants first,
+// truncates to whole microseconds, and ignores the timezone.
+// An encoding is the bitwise complement of the encoding produced by [TimeMicros].
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+// This Codec is lossy in the same way as [TimeMicros].
+func TimeMicrosDesc() Codec[time.Time] { return stdMicroDesc }
+
+// CivilDate returns a Codec for the [Date] type.
+// The encoded order is chronological.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// A Date is encoded as a 4-byte number of days since 1970-01-01, so no timezone is involved.
+// Dates are normalized as they are by [time.Date], and only normalized Dates round trip.
+func CivilDate() Codec[Date] { return stdDate }
+
+// CivilTimeOfDay returns a Codec for the [TimeOfDay] type.
+// The encoded order is chronological.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// A TimeOfDay is encoded as an 8-byte number of nanoseconds since midnight.
+// Only values whose fields are all within their normal ranges round trip.
+func CivilTimeOfDay() Codec[TimeOfDay] { return stdTimeOfDay }
+
+// CivilDateTime returns a Codec for the [DateTime] type.
+// The encoded order is chronological.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// A DateTime is encoded as its Date using [CivilDate], followed by its TimeOfDay using [CivilTimeOfDay],
+// for a total of 12 bytes.
+func CivilDateTime() Codec[DateTime] { return stdDateTime }
+
+// SemVer returns a Codec for the [Version] type, in Semantic Versioning 2.0.0 precedence order.
+// For example, 1.9.0 < 1.10.0 and 1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-beta < 1.0.0-rc.1 < 1.0.0.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// Numeric pre-release identifiers are ordered numerically, and before alphanumeric identifiers.
+// Build metadata is ignored for precedence, but is encoded after everything else so that it round trips.
+// Versions differing only in build metadata are ordered by their build identifiers.
+// A Version with an empty non-nil Prerelease or Build slice decodes with a nil slice.
+func SemVer() Codec[Version] { return stdSemVer }
+
 // Duration returns a Codec for the time.Duration type.
 // This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
 func Duration() Codec[time.Duration] { return stdDuration }
 
+// DurationDesc returns a Codec for the time.Duration type which orders longer durations first.
+// An encoding is the bitwise complement of the encoding produced by [Duration],
+// the same as [Negate]([Duration]()).
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+func DurationDesc() Codec[time.Duration] { return stdDurDesc }
+
 // BigInt returns a Codec for the *big.Int type, with nils ordered first.
 // This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
 func BigInt() Codec[*big.Int] { return stdBigInt }
@@ -294,6 +537,34 @@ func Bytes() Codec[[]byte] { return stdBytes }
 // This is a convenience function, it returns the same Codec as [Terminate]([Bytes]()).
 func TerminatedBytes() Codec[[]byte] { return stdTermBytes }
 
+// BitSet returns a Codec for the []bool type with the elements packed into bits, with nil slices ordered first.
+// The encoded order is the same as [SliceOf]([Bool]()), lexicographic by element with false < true,
+// and with a slice ordered before any longer slice it is a prefix of.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// A non-nil slice is encoded in 7 elements per byte, plus 2 bytes to encode its length.
+// Use [FixedBitSet] for a fixed number of flags, or [Uint64] for flags already stored as a bit mask,
+// whose encoded order is the same as comparing the flags from the most significant bit down.
+func BitSet() Codec[[]bool] { return stdBitSet }
+
+// FixedBitSet returns a Codec for the []bool type with at most size elements packed into bits.
+// The encoded order is lexicographic by element with false < true.
+// FixedBitSet will panic if size is negative.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator], unless size is 0.
+//
+// A slice is encoded as exactly (size+7)/8 bytes, with the elements packed big-endian.
+// Slices shorter than size, including nil, are encoded as if padded with false elements.
+// Get always returns a non-nil slice with exactly size elements.
+// This allows more flags to be added later, increasing size,
+// as long as the encoded length does not change and existing flags do not move.
+// Append and Put will panic if a slice has more than size elements.
+func FixedBitSet(size int) Codec[[]bool] {
+	if size < 0 {
+		panic(errNegativeSize)
+	}
+	return fixedBitSetCodec{size}
+}
+
 // PointerTo returns a Codec for the *E type, with nil pointers ordered first.
 // The encoded order of non-nil values is the same as is produced by elemCodec.
 // This Codec requires escaping if elemCodec does, as defined by [Codec.RequiresTerminator].
@@ -302,6 +573,17 @@ func PointerTo[E any](elemCodec Codec[E]) Codec[*E] {
 	return pointerCodec[E]{elemCodec, PrefixNilsFirst}
 }
 
+// OptionalOf returns a Codec for the [Optional] type, with absent values ordered first.
+// The encoded order of present values is the same as is produced by elemCodec.
+// This Codec requires escaping if elemCodec does, as defined by [Codec.RequiresTerminator].
+//
+// An Optional is encoded the same way [PointerTo] encodes a pointer to its value, or a nil pointer if it is absent.
+// Unlike PointerTo, Get does not allocate anything beyond what elemCodec allocates.
+func OptionalOf[E any](elemCodec Codec[E]) Codec[Optional[E]] {
+	elemCodec.RequiresTerminator() // force panic if nil
+	return optionalCodec[E]{elemCodec, PrefixNilsFirst}
+}
+
 // SliceOf returns a Codec for the []E type, with nil slices ordered first.
 // The encoded order is lexicographical using the encoded order of elemCodec for the elements.
 // This Codec requires escaping, as defined by [Codec.RequiresTerminator].
@@ -320,6 +602,144 @@ func MapOf[K comparable, V any](keyCodec Codec[K], valueCodec Codec[V]) Codec[ma
 	}
 }
 
+// ShortLexString returns a Codec for the string type in shortlex order.
+// Shorter strings are ordered first, and strings of the same length are ordered as they are by [String].
+// For example, "b" < "aa" < "ab".
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// A string is encoded as its length in bytes followed by its bytes.
+// The length is encoded in 1 to 9 bytes, the first of which is the number of bytes that follow it.
+// Because the length precedes the data, [Terminate] is not needed when this Codec is embedded in other encodings.
+func ShortLexString() Codec[string] { return stdSLString }
+
+// ShortLexBytes returns a Codec for the []byte type in shortlex order, with nil slices ordered first.
+// Other than allowing nil, this behaves the same as [ShortLexString].
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+func ShortLexBytes() Codec[[]byte] { return stdSLBytes }
+
+// ShortLexSliceOf returns a Codec for the []E type in shortlex order, with nil slices ordered first.
+// Shorter slices are ordered first, and slices with the same number of elements
+// are ordered lexicographically using the encoded order of elemCodec for the elements.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// A slice is encoded as its number of elements, encoded as it is by [ShortLexString], followed by its elements.
+// The elements are escaped and terminated if elemCodec requires it.
+func ShortLexSliceOf[E any](elemCodec Codec[E]) Codec[[]E] {
+	return shortLexSliceCodec[E]{Terminate(elemCodec), PrefixNilsFirst}
+}
+
+// Enum returns a Codec for a fixed set of values of type T, ordered as they are in values.
+// This is useful for named constants whose desired order is not the order of their underlying type.
+// Enum will panic if values contains duplicates.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// A value is encoded as its index in values, in the fewest big-endian bytes that can hold len(values),
+// which is 1 byte for up to 255 values.
+// Append and Put will panic if a value is not in values, and Get will panic if it reads an unknown index.
+// Values may be appended to values later without changing existing encodings, but not inserted or reordered,
+// and only if the number of bytes needed to encode an index does not change.
+// Use [EnumWithFallback] if values not yet known must be encodable.
+func Enum[T comparable](values ...T) Codec[T] {
+	return newEnumCodec(nil, values)
+}
+
+// EnumWithFallback returns a Codec like [Enum], except that values not in values are encoded using fallback,
+// and ordered after all values in values in the encoded order of fallback.
+// EnumWithFallback will panic if values contains duplicates.
+// This Codec requires escaping if fallback does, as defined by [Codec.RequiresTerminator].
+//
+// A value not in values is encoded as an index with all bits set, followed by its encoding by fallback.
+// This allows values to be added to the set without breaking existing encodings,
+// by using fallback until they can be migrated to values.
+func EnumWithFallback[T comparable](fallback Codec[T], values ...T) Codec[T] {
+	fallback.RequiresTerminator() // force panic if nil
+	return newEnumCodec(fallback, values)
+}
+
+// OneOf returns a Codec for values which may be any one of several variants,
+// such as the implementations of an interface.
+// A value is encoded with the first of variants whose Matches function returns true for it.
+// Values are ordered first by their variant's tag, and then by the encoded order of that variant's Codec.
+// OneOf will panic if two variants have the same tag.
+// This Codec requires escaping, as defined by [Codec.RequiresTerminator],
+// if and only if the Codec of any variant does.
+//
+// A value is encoded as the tag of its variant, followed by its encoding by that variant's Codec.
+// Append and Put will panic if no variant matches a value, and Get will panic if it reads an unknown tag.
+// [VariantOf] creates a Variant for a concrete type implementing an interface type T.
+func OneOf[T any](variants ...Variant[T]) Codec[T] {
+	for i := range variants {
+		for j := range i {
+			if variants[i].Tag == variants[j].Tag {
+				panic(duplicateTagError{variants[i].Tag})
+			}
+		}
+	}
+	return oneOfCodec[T]{append([]Variant[T]{}, variants...)}
+}
+
+// ZOrder2 returns a Codec for the [Point2] type in Z-order (also called Morton order),
+// which interleaves the bits of the encoded coordinates so that nearby points tend to have nearby encodings.
+// coordCodec must produce encodings of the same length for every value, like the integer and float Codecs.
+// ZOrder2 will panic if coordCodec requires escaping,
+// and Append and Put will panic if an encoding is a different length.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// A point is encoded by encoding X and Y with coordCodec, and then interleaving the bits of those encodings,
+// from most significant to least, with the bit from X first.
+// This preserves the order of either coordinate if the other is the same,
+// and the encoded order of all points in an aligned power-of-2 sized square is contiguous.
+// Use [ZOrder2Ranges] to compute the key ranges to scan for all points in a box.
+func ZOrder2[T any](coordCodec Codec[T]) Codec[Point2[T]] {
+	return zOrder2Codec[T]{newZCurve(coordCodec, 2)} //nolint:mnd
+}
+
+// ZOrder3 returns a Codec for the [Point3] type in Z-order,
+// exactly like [ZOrder2] except in three dimensions, with the bits of X, Y, and Z interleaved in that order.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// Use [ZOrder3Ranges] to compute the key ranges to scan for all points in a box.
+func ZOrder3[T any](coordCodec Codec[T]) Codec[Point3[T]] {
+	return zOrder3Codec[T]{newZCurve(coordCodec, 3)} //nolint:mnd
+}
+
+// Hilbert2D returns a Codec for the [Point2] type in the order of a Hilbert curve of order bits,
+// which has better locality than [ZOrder2], especially for long and narrow query rectangles.
+// coordCodec must produce encodings of the same length for every value, like the integer and float Codecs.
+// Hilbert2D will panic if coordCodec requires escaping, or if bits is not between 1 and 32 (inclusive),
+// or if bits is greater than the number of bits in an encoded coordinate.
+// Append and Put will panic if an encoding is a different length.
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// The most significant bits of each encoded coordinate select a cell in a grid with 2^bits cells per side.
+// A point is encoded as the index of its cell along the curve,
+// followed by the remaining bits of its encoded X coordinate and then its encoded Y coordinate.
+// The encoding has the same length as two encoded coordinates, and Get returns exactly the original point.
+// Points within the same cell are ordered by X and then Y,
+// so bits determines the size of the cells which [Hilbert2DRanges] will scan in their entirety.
+func Hilbert2D[T any](coordCodec Codec[T], bits int) Codec[Point2[T]] {
+	return newHilbert2Codec(coordCodec, bits)
+}
+
+// LatLng returns a Codec for the [GeoPoint] type, quantized into cells in the manner of a geohash,
+// so that nearby locations tend to have nearby encodings.
+// LatLng will panic if precisionBits is not between 1 and 64 (inclusive).
+// Append and Put will panic if a latitude is not within [-90, 90], or a longitude is not within [-180, 180].
+// This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
+//
+// Longitude and latitude are each divided into equal cells, using precisionBits bits in total,
+// with longitude getting the extra bit if precisionBits is odd.
+// The bits of the two cell indexes are interleaved, longitude first, and encoded in precisionBits/8 bytes, rounded up.
+// This is the same ordering as a geohash of the same number of bits.
+// For example, 52 bits gives cells smaller than a meter on each side.
+//
+// This Codec is lossy. Get returns the center of the cell containing the encoded location.
+// Use [LatLngRadiusRanges] to compute the key ranges to scan for all locations within a distance of a point,
+// and [LatLngNeighbors] to find the cells adjacent to a point's cell.
+func LatLng(precisionBits int) Codec[GeoPoint] {
+	return newLatLngCodec(precisionBits)
+}
+
 // Negate returns a Codec reversing the encoded order of codec.
 // This Codec does not require escaping, as defined by [Codec.RequiresTerminator].
 func Negate[T any](codec Codec[T]) Codec[T] {
@@ -356,7 +776,10 @@ type nillableCodec[T any] interface {
 }
 
 // NilsLast returns a Codec exactly like codec, but with nils ordered last.
-// NilsLast will panic if codec is not a pointer, slice, map, []byte, or *big.Int/Float/Rat Codec provided by lexy.
+// NilsLast will panic if codec is not a pointer, Optional, slice, map, []byte,
+// or *big.Int/Float/Rat Codec provided by lexy.
+// This includes the Codecs returned by [OptionalOf], [BitSet], [ShortLexBytes], and [ShortLexSliceOf],
+// and those Codecs when wrapped by [VariantOf] or described by [ParseSchema].
 // Codecs returned by [Negate] and [Terminate] will cause NilsLast to panic,
 // regardless of the Codec they are wrapping.
 func NilsLast[T any](codec Codec[T]) Codec[T] {
@@ -0,0 +1,82 @@
+package lexykv_test
+
+import (
+	"fmt"
+
+	"github.com/phiryll/lexy"
+	"github.com/phiryll/lexy/lexykv"
+)
+
+// Example shows two Tables sharing a KV, with keys which are tuples encoded by a schema Codec.
+func Example() {
+	var db lexykv.Memory
+	// Scores are keyed by game and descending score.
+	tupleCodec, err := lexy.ParseSchema("tuple(terminated(string), desc(int32))")
+	if err != nil {
+		panic(err)
+	}
+	// Table prefixes are encoded with a terminated Codec, so no prefix is a prefix of another.
+	prefixCodec := lexy.TerminatedString()
+	scores := lexykv.NewTable(&db, prefixCodec.Append(nil, "scores"), tupleCodec, lexy.String())
+	names := lexykv.NewTable(&db, prefixCodec.Append(nil, "names"), lexy.String(), lexy.Int32())
+
+	for _, item := range []struct {
+		game  string
+		score int32
+		name  string
+	}{
+		{"chess", 1200, "ann"},
+		{"chess", 1500, "bob"},
+		{"go", 900, "ann"},
+		{"chess", 800, "cat"},
+	} {
+		if err := scores.Put([]any{item.game, item.score}, item.name); err != nil {
+			panic(err)
+		}
+		if err := names.Put(item.name, item.score); err != nil {
+			panic(err)
+		}
+	}
+
+	// A game's scores are the keys prefixed by the encoding of the key's first element.
+	chess := lexy.TerminatedString().Append(nil, "chess")
+	fmt.Println("chess, highest first:")
+	err = scores.Prefix(chess, false, func(key any, name string) bool {
+		fmt.Println(key, name)
+		return true
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("chess, lowest first:")
+	err = scores.Prefix(chess, true, func(key any, name string) bool {
+		fmt.Println(key, name)
+		return true
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("names:")
+	err = names.All(false, func(name string, score int32) bool {
+		fmt.Println(name, score)
+		return true
+	})
+	if err != nil {
+		panic(err)
+	}
+	// Output:
+	// chess, highest first:
+	// [chess 1500] bob
+	// [chess 1200] ann
+	// [chess 800] cat
+	// chess, lowest first:
+	// [chess 800] cat
+	// [chess 1200] ann
+	// [chess 1500] bob
+	// names:
+	// ann 900
+	// bob 1500
+	// cat 800
+}
@@ -0,0 +1,217 @@
+package lexykv
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/phiryll/lexy"
+)
+
+// IndexKey is the key of a secondary index entry, an attribute value and the primary key of a record having it.
+type IndexKey[A, PK any] struct {
+	Attr    A
+	Primary PK
+}
+
+// indexKeyCodec encodes an IndexKey as its attribute followed by its primary key.
+// The attribute Codec must not require a terminator, so that the keys are ordered by attribute first.
+type indexKeyCodec[A, PK any] struct {
+	attrCodec    lexy.Codec[A]
+	primaryCodec lexy.Codec[PK]
+}
+
+func (c indexKeyCodec[A, PK]) Append(buf []byte, key IndexKey[A, PK]) []byte {
+	return c.primaryCodec.Append(c.attrCodec.Append(buf, key.Attr), key.Primary)
+}
+
+func (c indexKeyCodec[A, PK]) Put(buf []byte, key IndexKey[A, PK]) []byte {
+	return c.primaryCodec.Put(c.attrCodec.Put(buf, key.Attr), key.Primary)
+}
+
+func (c indexKeyCodec[A, PK]) Get(buf []byte) (IndexKey[A, PK], []byte) {
+	attr, buf := c.attrCodec.Get(buf)
+	primary, buf := c.primaryCodec.Get(buf)
+	return IndexKey[A, PK]{attr, primary}, buf
+}
+
+func (c indexKeyCodec[A, PK]) RequiresTerminator() bool {
+	return c.primaryCodec.RequiresTerminator()
+}
+
+// Indexer is implemented by secondary indexes of records of type R with primary keys of type PK.
+// [PutIndexed] and [DeleteIndexed] use Indexers to keep indexes in sync with a [Table] of records.
+type Indexer[PK, R any] interface {
+	// Update changes the index entries for the record with primary key pk from those for before to those for after.
+	// If before is nil, the record is being created, and if after is nil, the record is being deleted.
+	Update(pk PK, before, after *R) error
+}
+
+// Index is a secondary index of records of type R with primary keys of type PK, by an attribute of type A.
+// Each index entry is stored in a KV with an empty value, and a key consisting of the Index's prefix,
+// the encoded attribute, and the encoded primary key. The attribute is encoded with [lexy.Terminate] if necessary,
+// so that index entries are ordered by attribute, and then by primary key.
+//
+// An Index is a Table of those entries, and the Table's methods can be used to scan it directly.
+type Index[PK, R, A any] struct {
+	*Table[IndexKey[A, PK], struct{}]
+	attrCodec lexy.Codec[A]
+	extract   func(record R) []A
+}
+
+// NewIndex returns an Index of records by the attribute extract returns,
+// stored in kv with keys starting with prefix.
+// As with Tables, the prefix should not be a prefix of any other Table's or Index's prefix in kv.
+func NewIndex[PK, R, A any](
+	kv KV,
+	prefix []byte,
+	attrCodec lexy.Codec[A],
+	primaryCodec lexy.Codec[PK],
+	extract func(record R) A,
+) *Index[PK, R, A] {
+	return NewMultiIndex(kv, prefix, attrCodec, primaryCodec, func(record R) []A {
+		return []A{extract(record)}
+	})
+}
+
+// NewMultiIndex is like [NewIndex], except that a record may have any number of attribute values,
+// like the tags of a post, each of which is indexed.
+func NewMultiIndex[PK, R, A any](
+	kv KV,
+	prefix []byte,
+	attrCodec lexy.Codec[A],
+	primaryCodec lexy.Codec[PK],
+	extract func(record R) []A,
+) *Index[PK, R, A] {
+	if attrCodec.RequiresTerminator() {
+		attrCodec = lexy.Terminate(attrCodec)
+	}
+	keyCodec := indexKeyCodec[A, PK]{attrCodec, primaryCodec}
+	return &Index[PK, R, A]{NewTable(kv, prefix, keyCodec, lexy.Empty[struct{}]()), attrCodec, extract}
+}
+
+// Entries returns the encoded keys of the index entries for record, with primary key pk, in increasing order.
+// Duplicate attribute values produce a single entry.
+func (x *Index[PK, R, A]) Entries(pk PK, record R) [][]byte {
+	var keys [][]byte
+	for _, attr := range x.extract(record) {
+		keys = append(keys, x.Key(IndexKey[A, PK]{attr, pk}))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	return slices.CompactFunc(keys, bytes.Equal)
+}
+
+// Diff returns the encoded keys of the index entries to delete and to put when the record with primary key pk
+// changes from before to after, each in increasing order. Entries for both before and after are in neither.
+// If before is nil, the record is being created, and if after is nil, the record is being deleted.
+func (x *Index[PK, R, A]) Diff(pk PK, before, after *R) ([][]byte, [][]byte) {
+	var oldKeys, newKeys, deletes, puts [][]byte
+	if before != nil {
+		oldKeys = x.Entries(pk, *before)
+	}
+	if after != nil {
+		newKeys = x.Entries(pk, *after)
+	}
+	// Merge the two sorted lists.
+	for len(oldKeys) > 0 || len(newKeys) > 0 {
+		switch {
+		case len(newKeys) == 0:
+			return append(deletes, oldKeys...), puts
+		case len(oldKeys) == 0:
+			return deletes, append(puts, newKeys...)
+		}
+		switch bytes.Compare(oldKeys[0], newKeys[0]) {
+		case -1:
+			deletes = append(deletes, oldKeys[0])
+			oldKeys = oldKeys[1:]
+		case 1:
+			puts = append(puts, newKeys[0])
+			newKeys = newKeys[1:]
+		default:
+			oldKeys, newKeys = oldKeys[1:], newKeys[1:]
+		}
+	}
+	return deletes, puts
+}
+
+// Update applies the changes returned by [Index.Diff] to the Index's KV, deleting entries before putting them.
+// Update stops at the first error, possibly leaving the Index partially updated.
+func (x *Index[PK, R, A]) Update(pk PK, before, after *R) error {
+	deletes, puts := x.Diff(pk, before, after)
+	for _, key := range deletes {
+		if err := x.KV().Delete(key); err != nil {
+			return err
+		}
+	}
+	for _, key := range puts {
+		if err := x.KV().Put(key, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decode returns the attribute and primary key of the index entry with the encoded key,
+// as passed to the Index's KV. Decode will panic if key is not the key of an entry in the Index.
+func (x *Index[PK, R, A]) Decode(key []byte) IndexKey[A, PK] {
+	indexKey, _ := x.keyCodec.Get(key[len(x.prefix):])
+	return indexKey
+}
+
+// PrimaryKey returns the primary key of the index entry with the encoded key, as passed to the Index's KV.
+// PrimaryKey will panic if key is not the key of an entry in the Index.
+func (x *Index[PK, R, A]) PrimaryKey(key []byte) PK {
+	return x.Decode(key).Primary
+}
+
+// Lookup calls f with the primary key of each record having the attribute value attr,
+// in increasing primary key order, or in decreasing order if reverse is true, stopping if f returns false.
+func (x *Index[PK, R, A]) Lookup(attr A, reverse bool, f func(pk PK) bool) error {
+	return x.Prefix(x.attrCodec.Append(nil, attr), reverse, func(key IndexKey[A, PK], _ struct{}) bool {
+		return f(key.Primary)
+	})
+}
+
+// LookupRange calls f with the attribute value and primary key of each record
+// having an attribute value in [begin, end), ordered by attribute value and then by primary key.
+// The order is increasing, or decreasing if reverse is true, stopping if f returns false.
+func (x *Index[PK, R, A]) LookupRange(begin, end A, reverse bool, f func(attr A, pk PK) bool) error {
+	r := lexy.KeyRange{Begin: x.attrCodec.Append(nil, begin), End: x.attrCodec.Append(nil, end)}
+	return x.Scan(r, reverse, func(key IndexKey[A, PK], _ struct{}) bool {
+		return f(key.Attr, key.Primary)
+	})
+}
+
+// PutIndexed puts record in table with primary key pk, and updates indexes to match,
+// using the record previously in table, if any, to determine which index entries to delete.
+// The updates are not atomic, and PutIndexed stops at the first error.
+func PutIndexed[PK, R any](table *Table[PK, R], pk PK, record R, indexes ...Indexer[PK, R]) error {
+	old, found, err := table.Get(pk)
+	if err != nil {
+		return err
+	}
+	oldPtr := &old
+	if !found {
+		oldPtr = nil
+	}
+	for _, index := range indexes {
+		if err := index.Update(pk, oldPtr, &record); err != nil {
+			return err
+		}
+	}
+	return table.Put(pk, record)
+}
+
+// DeleteIndexed deletes the record with primary key pk from table, if any, and deletes its index entries.
+// The updates are not atomic, and DeleteIndexed stops at the first error.
+func DeleteIndexed[PK, R any](table *Table[PK, R], pk PK, indexes ...Indexer[PK, R]) error {
+	old, found, err := table.Get(pk)
+	if err != nil || !found {
+		return err
+	}
+	for _, index := range indexes {
+		if err := index.Update(pk, &old, nil); err != nil {
+			return err
+		}
+	}
+	return table.Delete(pk)
+}
@@ -0,0 +1,183 @@
+package lexykv_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+	"github.com/phiryll/lexy/lexykv"
+)
+
+type post struct {
+	Author string
+	Tags   []string
+	Score  int16
+}
+
+// postCodec encodes a post, which is never used in a key, so its order does not matter.
+type postCodec struct{}
+
+var tagsCodec = lexy.SliceOf(lexy.String())
+
+func (postCodec) Append(buf []byte, p post) []byte {
+	buf = lexy.TerminatedString().Append(buf, p.Author)
+	buf = lexy.Terminate(tagsCodec).Append(buf, p.Tags)
+	return lexy.Int16().Append(buf, p.Score)
+}
+
+func (postCodec) Put(buf []byte, p post) []byte {
+	buf = lexy.TerminatedString().Put(buf, p.Author)
+	buf = lexy.Terminate(tagsCodec).Put(buf, p.Tags)
+	return lexy.Int16().Put(buf, p.Score)
+}
+
+func (postCodec) Get(buf []byte) (post, []byte) {
+	author, buf := lexy.TerminatedString().Get(buf)
+	tags, buf := lexy.Terminate(tagsCodec).Get(buf)
+	score, buf := lexy.Int16().Get(buf)
+	return post{author, tags, score}, buf
+}
+
+func (postCodec) RequiresTerminator() bool {
+	return false
+}
+
+type postDB struct {
+	kv       *lexykv.Memory
+	posts    *lexykv.Table[uint32, post]
+	byAuthor *lexykv.Index[uint32, post, string]
+	byTag    *lexykv.Index[uint32, post, string]
+	byScore  *lexykv.Index[uint32, post, int16]
+}
+
+func newPostDB() postDB {
+	kv := &lexykv.Memory{}
+	return postDB{
+		kv,
+		lexykv.NewTable(kv, []byte{1}, lexy.Uint32(), lexy.Codec[post](postCodec{})),
+		lexykv.NewIndex(kv, []byte{2}, lexy.String(), lexy.Uint32(), func(p post) string { return p.Author }),
+		lexykv.NewMultiIndex(kv, []byte{3}, lexy.String(), lexy.Uint32(), func(p post) []string { return p.Tags }),
+		lexykv.NewIndex(kv, []byte{4}, lexy.Negate(lexy.Int16()), lexy.Uint32(), func(p post) int16 { return p.Score }),
+	}
+}
+
+func (db postDB) put(t *testing.T, pk uint32, p post) {
+	require.NoError(t, lexykv.PutIndexed(db.posts, pk, p, db.byAuthor, db.byTag, db.byScore))
+}
+
+func (db postDB) delete(t *testing.T, pk uint32) {
+	require.NoError(t, lexykv.DeleteIndexed(db.posts, pk, db.byAuthor, db.byTag, db.byScore))
+}
+
+// lookup returns the primary keys index.Lookup visits.
+func lookup[A any](t *testing.T, index *lexykv.Index[uint32, post, A], attr A, reverse bool) []uint32 {
+	var got []uint32
+	require.NoError(t, index.Lookup(attr, reverse, func(pk uint32) bool {
+		got = append(got, pk)
+		return true
+	}))
+	return got
+}
+
+func TestIndexEntries(t *testing.T) {
+	t.Parallel()
+	db := newPostDB()
+	p := post{"ann", []string{"go", "db", "go"}, 3}
+	assert.Equal(t, [][]byte{
+		append([]byte{3, 'd', 'b', 0}, 0x00, 0x00, 0x00, 0x07),
+		append([]byte{3, 'g', 'o', 0}, 0x00, 0x00, 0x00, 0x07),
+	}, db.byTag.Entries(7, p))
+	assert.Equal(t, [][]byte{append([]byte{2, 'a', 'n', 'n', 0}, 0x00, 0x00, 0x00, 0x07)}, db.byAuthor.Entries(7, p))
+	assert.Empty(t, db.byTag.Entries(7, post{}))
+
+	for _, key := range db.byTag.Entries(7, p) {
+		assert.Equal(t, uint32(7), db.byTag.PrimaryKey(key))
+	}
+	assert.Equal(t, lexykv.IndexKey[string, uint32]{Attr: "ann", Primary: 7},
+		db.byAuthor.Decode(db.byAuthor.Entries(7, p)[0]))
+}
+
+func TestIndexDiff(t *testing.T) {
+	t.Parallel()
+	db := newPostDB()
+	before := post{"ann", []string{"a", "b", "c"}, 1}
+	after := post{"ann", []string{"b", "d", "a"}, 2}
+	tagKey := func(tag string) []byte {
+		return db.byTag.Key(lexykv.IndexKey[string, uint32]{Attr: tag, Primary: 9})
+	}
+
+	deletes, puts := db.byTag.Diff(9, &before, &after)
+	assert.Equal(t, [][]byte{tagKey("c")}, deletes)
+	assert.Equal(t, [][]byte{tagKey("d")}, puts)
+
+	deletes, puts = db.byTag.Diff(9, nil, &after)
+	assert.Empty(t, deletes)
+	assert.Equal(t, [][]byte{tagKey("a"), tagKey("b"), tagKey("d")}, puts)
+
+	deletes, puts = db.byTag.Diff(9, &before, nil)
+	assert.Equal(t, [][]byte{tagKey("a"), tagKey("b"), tagKey("c")}, deletes)
+	assert.Empty(t, puts)
+
+	deletes, puts = db.byAuthor.Diff(9, &before, &after)
+	assert.Empty(t, deletes)
+	assert.Empty(t, puts)
+}
+
+func TestIndexed(t *testing.T) {
+	t.Parallel()
+	db := newPostDB()
+	db.put(t, 1, post{"ann", []string{"go", "db"}, 5})
+	db.put(t, 2, post{"bob", []string{"go"}, 9})
+	db.put(t, 3, post{"ann", nil, -2})
+	db.put(t, 4, post{"cat", []string{"db", "ops"}, 5})
+
+	assert.Equal(t, []uint32{1, 3}, lookup(t, db.byAuthor, "ann", false))
+	assert.Equal(t, []uint32{3, 1}, lookup(t, db.byAuthor, "ann", true))
+	assert.Equal(t, []uint32{1, 2}, lookup(t, db.byTag, "go", false))
+	assert.Equal(t, []uint32{1, 4}, lookup(t, db.byTag, "db", false))
+	assert.Empty(t, lookup(t, db.byTag, "g", false))
+
+	// Scores are indexed in descending order.
+	var scores []string
+	require.NoError(t, db.byScore.LookupRange(9, -2, false, func(score int16, pk uint32) bool {
+		scores = append(scores, fmt.Sprintf("%d:%d", score, pk))
+		return true
+	}))
+	assert.Equal(t, []string{"9:2", "5:1", "5:4"}, scores)
+
+	// Updating a record replaces its index entries.
+	db.put(t, 1, post{"bob", []string{"db", "ops"}, 5})
+	assert.Equal(t, []uint32{3}, lookup(t, db.byAuthor, "ann", false))
+	assert.Equal(t, []uint32{1, 2}, lookup(t, db.byAuthor, "bob", false))
+	assert.Equal(t, []uint32{2}, lookup(t, db.byTag, "go", false))
+	assert.Equal(t, []uint32{1, 4}, lookup(t, db.byTag, "ops", false))
+
+	// Deleting a record deletes its index entries, and deleting a missing record does nothing.
+	before := db.kv.Len()
+	db.delete(t, 1)
+	db.delete(t, 1)
+	assert.Equal(t, before-5, db.kv.Len())
+	assert.Equal(t, []uint32{2}, lookup(t, db.byAuthor, "bob", false))
+	assert.Equal(t, []uint32{4}, lookup(t, db.byTag, "db", false))
+	assert.Equal(t, []uint32{4}, lookup(t, db.byScore, 5, false))
+
+	// Every index entry refers to an existing record.
+	require.NoError(t, db.kv.Iterate(lexy.KeyRange{Begin: []byte{2}}, false, func(key, _ []byte) bool {
+		var pk uint32
+		switch key[0] {
+		case 2:
+			pk = db.byAuthor.PrimaryKey(key)
+		case 3:
+			pk = db.byTag.PrimaryKey(key)
+		default:
+			pk = db.byScore.PrimaryKey(key)
+		}
+		_, found, err := db.posts.Get(pk)
+		assert.NoError(t, err)
+		assert.True(t, found, "%X", key)
+		return true
+	}))
+}
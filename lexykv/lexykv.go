@@ -0,0 +1,144 @@
+/*
+Package lexykv provides typed access to ordered key-value stores using lexy Codecs.
+
+[KV] is a minimal interface to an ordered key-value store, with keys ordered by [bytes.Compare].
+It is easily implemented by wrapping most embedded and distributed key-value stores.
+[Memory] is an in-memory implementation, intended for tests.
+
+A [Table] is a keyspace within a KV, holding the entries whose keys start with a fixed prefix.
+It encodes keys and values using Codecs, and supports point lookups and range scans over decoded keys and values,
+in either direction.
+
+	users := lexykv.NewTable(db, []byte("users/"), userKeyCodec, userCodec)
+	err := users.Put(UserKey{"acme", 7}, user)
+	...
+	err = users.Range(UserKey{"acme", 0}, UserKey{"acme", 100}, false, func(key UserKey, user User) bool {
+		fmt.Println(key, user.Name)
+		return true
+	})
+
+An [Index] is a secondary index of a Table's records by an attribute, itself stored as a Table
+whose keys are the attribute followed by the primary key. [PutIndexed] and [DeleteIndexed] keep indexes in sync
+with a Table, and an Index can also compute the entries to change for an update with [Index.Diff].
+
+	byEmail := lexykv.NewIndex(db, []byte("users-by-email/"), lexy.String(), userKeyCodec,
+		func(user User) string { return user.Email })
+	err := lexykv.PutIndexed(users, UserKey{"acme", 7}, user, byEmail)
+	...
+	err = byEmail.Lookup("ann@example.com", false, func(key UserKey) bool { ... })
+
+The encoded key of a Table entry is its prefix followed by the encoded key.
+If one Table's prefix is a prefix of another's, the first Table will contain the second Table's entries,
+and will likely fail to decode them. Using prefixes of the same length, or prefixes encoded by a Codec
+whose encodings are never prefixes of one another (like [lexy.TerminatedString]), avoids this.
+*/
+package lexykv
+
+import "github.com/phiryll/lexy"
+
+// KV is an ordered key-value store, with keys ordered by [bytes.Compare].
+//
+// The keys and values passed to KV methods may be modified by the caller after the method returns,
+// so implementations must copy them if they are retained.
+type KV interface {
+	// Get returns the value for key, and true if it was found.
+	Get(key []byte) ([]byte, bool, error)
+
+	// Put sets the value for key, replacing any existing value.
+	Put(key, value []byte) error
+
+	// Delete removes the entry for key, if any.
+	Delete(key []byte) error
+
+	// Iterate calls f for each entry whose key is within r, in increasing key order,
+	// or in decreasing key order if reverse is true, stopping if f returns false.
+	// The key and value passed to f must not be modified, and are only valid until f returns.
+	// f may modify the KV, but whether the iteration sees those modifications depends on the implementation.
+	Iterate(r lexy.KeyRange, reverse bool, f func(key, value []byte) bool) error
+}
+
+// Table is a typed keyspace within a [KV], the entries whose encoded keys start with a fixed prefix.
+// Keys are encoded with a Codec[K], and values with a Codec[V].
+//
+// The Codecs are used to decode keys and values read from the KV, and will panic if those cannot be decoded,
+// exactly as [lexy.Codec.Get] would.
+type Table[K, V any] struct {
+	kv         KV
+	prefix     []byte
+	keyCodec   lexy.Codec[K]
+	valueCodec lexy.Codec[V]
+}
+
+// NewTable returns a Table for the entries in kv whose encoded keys start with prefix.
+// The prefix may be empty, in which case the Table contains every entry in kv.
+func NewTable[K, V any](kv KV, prefix []byte, keyCodec lexy.Codec[K], valueCodec lexy.Codec[V]) *Table[K, V] {
+	return &Table[K, V]{kv, append([]byte{}, prefix...), keyCodec, valueCodec}
+}
+
+// KV returns the KV containing t.
+func (t *Table[K, V]) KV() KV {
+	return t.kv
+}
+
+// Key returns the encoded key for key within the KV, t's prefix followed by the encoding of key.
+func (t *Table[K, V]) Key(key K) []byte {
+	return t.keyCodec.Append(append([]byte{}, t.prefix...), key)
+}
+
+// Get returns the value for key, and true if it was found.
+// If it was not found, this returns the zero value of V.
+func (t *Table[K, V]) Get(key K) (V, bool, error) {
+	var zero V
+	value, found, err := t.kv.Get(t.Key(key))
+	if err != nil || !found {
+		return zero, false, err
+	}
+	v, _ := t.valueCodec.Get(value)
+	return v, true, nil
+}
+
+// Put sets the value for key, replacing any existing value.
+func (t *Table[K, V]) Put(key K, value V) error {
+	return t.kv.Put(t.Key(key), t.valueCodec.Append(nil, value))
+}
+
+// Delete removes the entry for key, if any.
+func (t *Table[K, V]) Delete(key K) error {
+	return t.kv.Delete(t.Key(key))
+}
+
+// Range calls f for each entry with a key in [begin, end), in increasing key order,
+// or in decreasing key order if reverse is true, stopping if f returns false.
+func (t *Table[K, V]) Range(begin, end K, reverse bool, f func(key K, value V) bool) error {
+	r := lexy.KeyRange{Begin: t.keyCodec.Append(nil, begin), End: t.keyCodec.Append(nil, end)}
+	return t.Scan(r, reverse, f)
+}
+
+// Prefix calls f for each entry whose encoded key, not including t's prefix, starts with prefix.
+// Entries are visited in increasing key order, or in decreasing key order if reverse is true,
+// stopping if f returns false.
+//
+// The prefix is usually the encoding of the leading fields of a key,
+// written by the same Codecs the key's Codec uses for those fields.
+func (t *Table[K, V]) Prefix(prefix []byte, reverse bool, f func(key K, value V) bool) error {
+	return t.Scan(lexy.KeyRange{}.WithPrefix(prefix), reverse, f)
+}
+
+// All calls f for each entry in t, in increasing key order,
+// or in decreasing key order if reverse is true, stopping if f returns false.
+func (t *Table[K, V]) All(reverse bool, f func(key K, value V) bool) error {
+	return t.Scan(lexy.KeyRange{}, reverse, f)
+}
+
+// Scan calls f for each entry whose encoded key, not including t's prefix, is within r.
+// Entries are visited in increasing key order, or in decreasing key order if reverse is true,
+// stopping if f returns false.
+//
+// Scan accepts the KeyRanges returned by functions like [lexy.ZOrder2Ranges].
+func (t *Table[K, V]) Scan(r lexy.KeyRange, reverse bool, f func(key K, value V) bool) error {
+	return t.kv.Iterate(r.WithPrefix(t.prefix), reverse, func(key, value []byte) bool {
+		k, _ := t.keyCodec.Get(key[len(t.prefix):])
+		v, _ := t.valueCodec.Get(value)
+		return f(k, v)
+	})
+}
@@ -0,0 +1,102 @@
+package lexykv
+
+import (
+	"bytes"
+	"slices"
+	"sync"
+
+	"github.com/phiryll/lexy"
+)
+
+// Memory is an in-memory [KV], storing its entries in a sorted slice.
+// It is intended for tests and small data sets, as Put and Delete take time proportional to the number of entries.
+//
+// The zero value is an empty Memory ready to use. A Memory is safe for concurrent use.
+// Iterate visits the entries as they were when it was called, so f may modify the Memory.
+type Memory struct {
+	mu      sync.RWMutex
+	entries []entry // sorted by key
+}
+
+type entry struct {
+	key, value []byte
+}
+
+// search returns the index of key in m.entries, or where it would be inserted, and true if it was found.
+func (m *Memory) search(key []byte) (int, bool) {
+	return slices.BinarySearchFunc(m.entries, key, func(e entry, key []byte) int {
+		return bytes.Compare(e.key, key)
+	})
+}
+
+// Len returns the number of entries in m.
+func (m *Memory) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.entries)
+}
+
+// Get returns the value for key, and true if it was found.
+// The returned value must not be modified.
+func (m *Memory) Get(key []byte) ([]byte, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if i, found := m.search(key); found {
+		return m.entries[i].value, true, nil
+	}
+	return nil, false, nil
+}
+
+// Put sets the value for key, replacing any existing value.
+func (m *Memory) Put(key, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Entries are never modified once added, only replaced, so Iterate's snapshots remain valid.
+	e := entry{append([]byte{}, key...), append([]byte{}, value...)}
+	if i, found := m.search(key); found {
+		m.entries[i] = e
+	} else {
+		m.entries = slices.Insert(m.entries, i, e)
+	}
+	return nil
+}
+
+// Delete removes the entry for key, if any.
+func (m *Memory) Delete(key []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if i, found := m.search(key); found {
+		m.entries = slices.Delete(m.entries, i, i+1)
+	}
+	return nil
+}
+
+// Iterate calls f for each entry whose key is within r, in increasing key order,
+// or in decreasing key order if reverse is true, stopping if f returns false.
+func (m *Memory) Iterate(r lexy.KeyRange, reverse bool, f func(key, value []byte) bool) error {
+	for _, e := range m.snapshot(r, reverse) {
+		if !f(e.key, e.value) {
+			break
+		}
+	}
+	return nil
+}
+
+// snapshot returns a copy of the entries within r, in the order Iterate visits them.
+func (m *Memory) snapshot(r lexy.KeyRange, reverse bool) []entry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	begin, _ := m.search(r.Begin)
+	end := len(m.entries)
+	if r.End != nil {
+		end, _ = m.search(r.End)
+	}
+	if end <= begin {
+		return nil
+	}
+	entries := slices.Clone(m.entries[begin:end])
+	if reverse {
+		slices.Reverse(entries)
+	}
+	return entries
+}
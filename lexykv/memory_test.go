@@ -0,0 +1,95 @@
+package lexykv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+	"github.com/phiryll/lexy/lexykv"
+)
+
+// entries returns the keys and values visited by kv.Iterate, as strings.
+func entries(t *testing.T, kv lexykv.KV, r lexy.KeyRange, reverse bool) []string {
+	var got []string
+	require.NoError(t, kv.Iterate(r, reverse, func(key, value []byte) bool {
+		got = append(got, string(key)+"="+string(value))
+		return true
+	}))
+	return got
+}
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+	var db lexykv.Memory
+	for _, key := range []string{"b", "a", "c", "ab", ""} {
+		require.NoError(t, db.Put([]byte(key), []byte(key+"1")))
+	}
+	require.NoError(t, db.Put([]byte("b"), []byte("b2")))
+	assert.Equal(t, 5, db.Len())
+
+	value, found, err := db.Get([]byte("b"))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("b2"), value)
+	_, found, err = db.Get([]byte("bb"))
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, db.Delete([]byte("ab")))
+	require.NoError(t, db.Delete([]byte("missing")))
+	assert.Equal(t, 4, db.Len())
+
+	assert.Equal(t, []string{"=1", "a=a1", "b=b2", "c=c1"}, entries(t, &db, lexy.KeyRange{}, false))
+	assert.Equal(t, []string{"c=c1", "b=b2", "a=a1", "=1"}, entries(t, &db, lexy.KeyRange{}, true))
+	assert.Equal(t, []string{"a=a1", "b=b2"}, entries(t, &db, lexy.KeyRange{Begin: []byte("a"), End: []byte("c")}, false))
+	assert.Equal(t, []string{"b=b2", "a=a1"}, entries(t, &db, lexy.KeyRange{Begin: []byte("a"), End: []byte("c")}, true))
+	assert.Equal(t, []string{"b=b2", "c=c1"}, entries(t, &db, lexy.KeyRange{Begin: []byte("aa")}, false))
+	assert.Empty(t, entries(t, &db, lexy.KeyRange{Begin: []byte("c"), End: []byte("a")}, false))
+}
+
+func TestMemoryCopies(t *testing.T) {
+	t.Parallel()
+	var db lexykv.Memory
+	key, value := []byte("k"), []byte("v")
+	require.NoError(t, db.Put(key, value))
+	key[0], value[0] = 'x', 'x'
+	got, found, err := db.Get([]byte("k"))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("v"), got)
+}
+
+func TestMemoryIterateStop(t *testing.T) {
+	t.Parallel()
+	var db lexykv.Memory
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, db.Put([]byte(key), nil))
+	}
+	var got []string
+	require.NoError(t, db.Iterate(lexy.KeyRange{}, false, func(key, _ []byte) bool {
+		got = append(got, string(key))
+		return len(got) < 2
+	}))
+	assert.Equal(t, []string{"a", "b"}, got)
+}
+
+func TestMemoryIterateModify(t *testing.T) {
+	t.Parallel()
+	var db lexykv.Memory
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, db.Put([]byte(key), []byte(key)))
+	}
+	// Iterate visits the entries as they were when it was called.
+	var got []string
+	require.NoError(t, db.Iterate(lexy.KeyRange{}, false, func(key, value []byte) bool {
+		got = append(got, string(key)+"="+string(value))
+		assert.NoError(t, db.Delete(key))
+		assert.NoError(t, db.Put([]byte("b"), []byte("x")))
+		assert.NoError(t, db.Put([]byte("d"), []byte("d")))
+		return true
+	}))
+	assert.Equal(t, []string{"a=a", "b=b", "c=c"}, got)
+	assert.Equal(t, []string{"b=x", "d=d"}, entries(t, &db, lexy.KeyRange{}, false))
+}
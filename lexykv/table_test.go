@@ -0,0 +1,192 @@
+package lexykv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+	"github.com/phiryll/lexy/lexykv"
+)
+
+var (
+	regionCodec = lexy.TerminatedString()
+	idCodec     = lexy.Int32()
+)
+
+type userKey struct {
+	region string
+	id     int32
+}
+
+// userKeyCodec encodes a userKey as its region followed by its ID.
+type userKeyCodec struct{}
+
+func (userKeyCodec) Append(buf []byte, key userKey) []byte {
+	return idCodec.Append(regionCodec.Append(buf, key.region), key.id)
+}
+
+func (userKeyCodec) Put(buf []byte, key userKey) []byte {
+	return idCodec.Put(regionCodec.Put(buf, key.region), key.id)
+}
+
+func (userKeyCodec) Get(buf []byte) (userKey, []byte) {
+	region, buf := regionCodec.Get(buf)
+	id, buf := idCodec.Get(buf)
+	return userKey{region, id}, buf
+}
+
+func (userKeyCodec) RequiresTerminator() bool {
+	return false
+}
+
+type entry[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// collect returns a callback for Table scans appending the entries it is called with to got.
+func collect[K, V any](got *[]entry[K, V]) func(K, V) bool {
+	return func(key K, value V) bool {
+		*got = append(*got, entry[K, V]{key, value})
+		return true
+	}
+}
+
+func newUsers(t *testing.T, kv lexykv.KV) *lexykv.Table[userKey, string] {
+	users := lexykv.NewTable(kv, []byte("users\x00"), lexy.Codec[userKey](userKeyCodec{}), lexy.String())
+	for _, key := range []userKey{{"us", 2}, {"eu", 1}, {"us", -1}, {"eu", 3}, {"usa", 0}, {"", 5}} {
+		require.NoError(t, users.Put(key, key.region+"-user"))
+	}
+	return users
+}
+
+func TestTableGetPutDelete(t *testing.T) {
+	t.Parallel()
+	var db lexykv.Memory
+	users := newUsers(t, &db)
+	assert.Equal(t, 6, db.Len())
+	assert.Same(t, &db, users.KV())
+
+	value, found, err := users.Get(userKey{"eu", 3})
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "eu-user", value)
+
+	require.NoError(t, users.Put(userKey{"eu", 3}, "replaced"))
+	value, _, err = users.Get(userKey{"eu", 3})
+	require.NoError(t, err)
+	assert.Equal(t, "replaced", value)
+
+	require.NoError(t, users.Delete(userKey{"eu", 3}))
+	value, found, err = users.Get(userKey{"eu", 3})
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Empty(t, value)
+
+	raw, found, err := db.Get(users.Key(userKey{"us", 2}))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("us-user"), raw)
+	assert.Equal(t, append([]byte("users\x00us\x00"), 0x80, 0x00, 0x00, 0x02), users.Key(userKey{"us", 2}))
+}
+
+func TestTableScans(t *testing.T) {
+	t.Parallel()
+	var db lexykv.Memory
+	users := newUsers(t, &db)
+	// Entries outside the Table are not visited.
+	require.NoError(t, db.Put([]byte("users"), []byte("before")))
+	require.NoError(t, db.Put([]byte("users\x01"), []byte("after")))
+	require.NoError(t, db.Put([]byte("usert"), []byte("after")))
+
+	type userEntry = entry[userKey, string]
+	all := []userEntry{
+		{userKey{"", 5}, "-user"},
+		{userKey{"eu", 1}, "eu-user"},
+		{userKey{"eu", 3}, "eu-user"},
+		{userKey{"us", -1}, "us-user"},
+		{userKey{"us", 2}, "us-user"},
+		{userKey{"usa", 0}, "usa-user"},
+	}
+	reversed := func(entries []userEntry) []userEntry {
+		var result []userEntry
+		for i := len(entries) - 1; i >= 0; i-- {
+			result = append(result, entries[i])
+		}
+		return result
+	}
+	for _, tt := range []struct {
+		name string
+		scan func(reverse bool, f func(userKey, string) bool) error
+		want []userEntry
+	}{
+		{"all", users.All, all},
+		{
+			"range",
+			func(reverse bool, f func(userKey, string) bool) error {
+				return users.Range(userKey{"eu", 2}, userKey{"us", 2}, reverse, f)
+			},
+			all[2:4],
+		},
+		{
+			"empty range",
+			func(reverse bool, f func(userKey, string) bool) error {
+				return users.Range(userKey{"us", 2}, userKey{"eu", 2}, reverse, f)
+			},
+			nil,
+		},
+		{
+			"prefix",
+			func(reverse bool, f func(userKey, string) bool) error {
+				return users.Prefix(regionCodec.Append(nil, "us"), reverse, f)
+			},
+			all[3:5],
+		},
+		{
+			"scan",
+			func(reverse bool, f func(userKey, string) bool) error {
+				return users.Scan(lexy.KeyRange{Begin: regionCodec.Append(nil, "eu")}, reverse, f)
+			},
+			all[1:],
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []userEntry
+			require.NoError(t, tt.scan(false, collect(&got)))
+			assert.Equal(t, tt.want, got)
+			got = nil
+			require.NoError(t, tt.scan(true, collect(&got)))
+			assert.Equal(t, reversed(tt.want), got)
+		})
+	}
+}
+
+func TestTableStop(t *testing.T) {
+	t.Parallel()
+	var db lexykv.Memory
+	users := newUsers(t, &db)
+	var got []userKey
+	require.NoError(t, users.All(true, func(key userKey, _ string) bool {
+		got = append(got, key)
+		return len(got) < 2
+	}))
+	assert.Equal(t, []userKey{{"usa", 0}, {"us", 2}}, got)
+}
+
+func TestTableEmptyPrefix(t *testing.T) {
+	t.Parallel()
+	var db lexykv.Memory
+	table := lexykv.NewTable(&db, nil, lexy.Uint8(), lexy.Bool())
+	for _, key := range []uint8{0, 255, 7} {
+		require.NoError(t, table.Put(key, key > 100))
+	}
+	var got []entry[uint8, bool]
+	require.NoError(t, table.All(false, collect(&got)))
+	assert.Equal(t, []entry[uint8, bool]{{0, false}, {7, false}, {255, true}}, got)
+	got = nil
+	require.NoError(t, table.Prefix([]byte{0xFF}, false, collect(&got)))
+	assert.Equal(t, []entry[uint8, bool]{{255, true}}, got)
+}
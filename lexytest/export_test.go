@@ -0,0 +1,41 @@
+package lexytest
+
+// Things that need to be exported for testing, but should not be part of the public API.
+// The identifiers are in the lexytest package, but the filename ends in _test.go,
+// preventing their inclusion in the public API.
+
+import "github.com/phiryll/lexy"
+
+// TestingCheck returns the errors Run would report for codec and orderedValues, keyed by subtest name.
+// Only subtests with errors are included.
+func TestingCheck[T any](codec lexy.Codec[T], orderedValues []T, equal func(a, b T) bool) map[string]error {
+	errs := map[string]error{}
+	for _, check := range newChecker(codec, equal).checks(orderedValues) {
+		if err := recovered(check.check); err != nil {
+			errs[check.name] = err
+		}
+	}
+	return errs
+}
+
+// TestingFuzzCheck returns the check Fuzz performs for each pair of generator inputs.
+func TestingFuzzCheck[T any](
+	codec lexy.Codec[T],
+	generator func([]byte) T,
+	cmp func(a, b T) int,
+) func(a, b []byte) error {
+	check := newFuzzCheck(codec, generator, cmp)
+	return func(a, b []byte) error {
+		return recovered(func() error { return check(a, b) })
+	}
+}
+
+// TestingWriteGolden writes a golden file as Golden does with the -lexytest.update flag.
+func TestingWriteGolden[T any](codec lexy.Codec[T], path string, values []T) error {
+	return newChecker(codec, nil).writeGolden(path, values)
+}
+
+// TestingCheckGolden returns the errors Golden would report.
+func TestingCheckGolden[T any](codec lexy.Codec[T], path string, values []T, equal func(a, b T) bool) error {
+	return recovered(func() error { return newChecker(codec, equal).checkGoldenFile(path, values) })
+}
@@ -0,0 +1,100 @@
+package lexytest
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/phiryll/lexy"
+)
+
+// fuzzSeeds are the inputs to a generator passed to Fuzz, chosen to include bytes with special meanings to lexy.
+var fuzzSeeds = [][]byte{
+	{},
+	{0x00},
+	{0x01},
+	{0x02},
+	{0x7F},
+	{0x80},
+	{0xFF},
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+	{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+	{0x00, 0x01, 0x02, 0x03, 0xFD, 0xFE, 0xFF, 0x80, 0x7F, 0x41, 0x30, 0x2E},
+	[]byte("a/b.c-1"),
+}
+
+// Fuzz runs a fuzz test of codec, using generator to create values from arbitrary bytes,
+// and cmp to compare values in the order codec is expected to encode them.
+// generator must return the same value every time for the same input.
+//
+// Fuzz registers seeds for generator's input, and the fuzz target has two []byte arguments,
+// each of which is passed to generator to create a value.
+// More seeds can be added by calling f.Add with two []byte arguments before calling Fuzz.
+// For each pair of values a and b, Fuzz checks that:
+//
+//   - each value passes the same checks that [Run] performs for a single value,
+//     using cmp(x, y) == 0 as the equality function
+//   - bytes.Compare(codec.Append(nil, a), codec.Append(nil, b)) agrees with cmp(a, b)
+//   - the encoding of a followed by the encoding of b decodes to a and then b,
+//     using [lexy.Terminate] to encode both if codec.RequiresTerminator() is true,
+//     as would be the case if a and b were elements of a larger encoding
+//
+// Panics from codec and generator are reported as test failures.
+func Fuzz[T any](f *testing.F, codec lexy.Codec[T], generator func([]byte) T, cmp func(a, b T) int) {
+	f.Helper()
+	for i, a := range fuzzSeeds {
+		for _, b := range fuzzSeeds[i:] {
+			f.Add(a, b)
+		}
+	}
+	check := newFuzzCheck(codec, generator, cmp)
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		report(t, func() error { return check(a, b) })
+	})
+}
+
+// newFuzzCheck returns the check performed by Fuzz for each pair of generator inputs.
+func newFuzzCheck[T any](
+	codec lexy.Codec[T],
+	generator func([]byte) T,
+	cmpFunc func(a, b T) int,
+) func(a, b []byte) error {
+	c := newChecker(codec, func(a, b T) bool { return cmpFunc(a, b) == 0 })
+	sequenceCodec := c.codec
+	if c.codec.RequiresTerminator() {
+		sequenceCodec = lexy.Terminate(c.codec)
+	}
+	return func(aInput, bInput []byte) error {
+		a, b := generator(aInput), generator(bInput)
+		return errors.Join(
+			c.checkValue(a),
+			c.checkValue(b),
+			c.checkPair(a, b, cmpFunc),
+			c.checkSequence(sequenceCodec, a, b),
+		)
+	}
+}
+
+// checkPair checks that the encodings of a and b compare the same way cmpFunc compares a and b.
+func (c checker[T]) checkPair(a, b T, cmpFunc func(a, b T) int) error {
+	aEncoded, bEncoded := c.codec.Append(nil, a), c.codec.Append(nil, b)
+	want, got := cmp.Compare(cmpFunc(a, b), 0), bytes.Compare(aEncoded, bEncoded)
+	if want != got {
+		return fmt.Errorf("cmp(%v, %v) = %d, but bytes.Compare(%X, %X) = %d", a, b, want, aEncoded, bEncoded, got)
+	}
+	return nil
+}
+
+// checkSequence checks that a and b, each encoded by codec and then concatenated, decode in sequence.
+func (c checker[T]) checkSequence(codec lexy.Codec[T], a, b T) error {
+	buf := codec.Append(codec.Append(nil, a), b)
+	gotA, rest := codec.Get(buf)
+	gotB, rest := codec.Get(rest)
+	if !c.equal(a, gotA) || !c.equal(b, gotB) || len(rest) != 0 {
+		return fmt.Errorf("concatenated encodings %X decoded to %v and %v with %X unread, want %v and %v",
+			buf, gotA, gotB, rest, a, b)
+	}
+	return nil
+}
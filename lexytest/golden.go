@@ -0,0 +1,151 @@
+package lexytest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/phiryll/lexy"
+)
+
+var update = flag.Bool("lexytest.update", false, "rewrite golden files checked by lexytest.Golden")
+
+// emptyEncoding is written to golden files in place of an empty hex string.
+const emptyEncoding = "-"
+
+// Golden checks that codec's encodings of values have not changed from those recorded in the golden file at path,
+// and that Get still decodes each recorded encoding to a value equal to the original value.
+// equal is used to compare values, and if nil, [reflect.DeepEqual] is used instead.
+// This protects persisted data from accidental changes to a Codec's encoding, including those in lexy itself.
+//
+// The golden file has one line per value, the hex encoding of the value followed by a description of the value,
+// in the same order as values. An empty encoding is written as "-", and lines starting with "#" are ignored.
+// The descriptions are for readers, and are not checked.
+// Golden fails if the file does not exist, or has a different number of encodings than there are values.
+//
+// Running tests with the -lexytest.update flag writes the golden file instead of checking it,
+// creating any missing directories. Only do this when creating a golden file or deliberately changing an encoding,
+// as any encodings already persisted will no longer decode correctly.
+//
+//	go test . -run TestMyGolden -lexytest.update
+//
+// The flag is only defined in test binaries which import lexytest, so use it only for those packages.
+func Golden[T any](t *testing.T, codec lexy.Codec[T], path string, values []T, equal func(a, b T) bool) {
+	t.Helper()
+	c := newChecker(codec, equal)
+	if *update {
+		if err := recovered(func() error { return c.writeGolden(path, values) }); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("wrote golden file %s", path)
+		return
+	}
+	report(t, func() error { return c.checkGoldenFile(path, values) })
+}
+
+func (c checker[T]) checkGoldenFile(path string, values []T) error {
+	encodings, err := readGolden(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w, run with -lexytest.update to create it", err)
+	}
+	if err != nil {
+		return err
+	}
+	if len(encodings) != len(values) {
+		return fmt.Errorf("golden file %s has %d encodings, but there are %d values", path, len(encodings), len(values))
+	}
+	errs := make([]error, len(values))
+	for i, value := range values {
+		errs[i] = c.checkGolden(value, encodings[i])
+	}
+	return errors.Join(errs...)
+}
+
+func (c checker[T]) checkGolden(value T, golden goldenLine) error {
+	encodeErr := recovered(func() error {
+		if got := c.codec.Append(nil, value); !bytes.Equal(golden.data, got) {
+			return fmt.Errorf("encoding of %v changed from %X to %X", value, golden.data, got)
+		}
+		return nil
+	})
+	decodeErr := recovered(func() error {
+		if got, rest := c.codec.Get(golden.data); !c.equal(value, got) || len(rest) != 0 {
+			return fmt.Errorf("golden encoding %X decoded to %v with %X unread, want %v", golden.data, got, rest, value)
+		}
+		return nil
+	})
+	if err := errors.Join(encodeErr, decodeErr); err != nil {
+		return fmt.Errorf("line %d: %w", golden.lineNum, err)
+	}
+	return nil
+}
+
+func (c checker[T]) writeGolden(path string, values []T) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Golden encodings, one per line: hex encoding, then a description of the value.\n")
+	fmt.Fprintf(&buf, "# Written by lexytest.Golden, do not edit.\n")
+	for _, value := range values {
+		encoded := hex.EncodeToString(c.codec.Append(nil, value))
+		if encoded == "" {
+			encoded = emptyEncoding
+		}
+		fmt.Fprintf(&buf, "%s %s\n", encoded, strings.ReplaceAll(describe(value), "\n", `\n`))
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// describe returns a description of value which does not depend on the addresses of any pointers.
+func describe(value any) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return "nil"
+	}
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	if v.Kind() == reflect.Pointer {
+		return "&" + describe(v.Elem().Interface())
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+type goldenLine struct {
+	lineNum int
+	data    []byte
+}
+
+func readGolden(path string) ([]goldenLine, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var lines []goldenLine
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		field, _, _ := strings.Cut(line, " ")
+		data := []byte{}
+		if field != emptyEncoding {
+			if data, err = hex.DecodeString(field); err != nil {
+				return nil, fmt.Errorf("golden file %s line %d: %w", path, lineNum, err)
+			}
+		}
+		lines = append(lines, goldenLine{lineNum, data})
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,49 @@
+package lexytest_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+	"github.com/phiryll/lexy/lexytest"
+)
+
+func TestGolden(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "sub", "golden.hex")
+	values := []*string{nil, new(string), ptr("a\nb")}
+	codec := lexy.PointerTo(lexy.String())
+	require.NoError(t, lexytest.TestingWriteGolden(codec, path, values))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(string(data), "\n")
+	assert.Equal(t, []string{`02 nil`, `03 &""`, `03610a62 &"a\nb"`, ``}, lines[2:])
+
+	lexytest.Golden(t, codec, path, values, nil)
+	require.NoError(t, lexytest.TestingCheckGolden(codec, path, values, nil))
+
+	err = lexytest.TestingCheckGolden(codec, path, values[:2], nil)
+	require.ErrorContains(t, err, "has 3 encodings, but there are 2 values")
+
+	// A different Codec for the same type, as if the encoding had changed.
+	err = lexytest.TestingCheckGolden(lexy.NilsLast(codec), path, values, nil)
+	require.ErrorContains(t, err, "line 3: encoding of <nil> changed from 02 to FD")
+	require.ErrorContains(t, err, "unexpected panic: read nils-first prefix when nils-last was configured")
+
+	err = lexytest.TestingCheckGolden(codec, filepath.Join(t.TempDir(), "missing.hex"), values, nil)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.ErrorContains(t, err, "run with -lexytest.update to create it")
+
+	require.NoError(t, os.WriteFile(path, []byte("# comment\n\n- empty\n0x12 bad\n"), 0o600))
+	err = lexytest.TestingCheckGolden(lexy.Empty[int](), path, []int{0, 0}, nil)
+	require.ErrorContains(t, err, "line 4: encoding/hex: invalid byte")
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
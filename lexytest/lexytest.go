@@ -0,0 +1,290 @@
+/*
+Package lexytest provides conformance tests for implementations of [lexy.Codec].
+
+[Run] checks that a Codec behaves the same way lexy's own Codecs do, for a list of values in their intended order.
+It is meant for user-defined Codecs, and for Codecs composed from lexy's Codecs,
+to be called from within an ordinary test function.
+
+	func TestMyCodec(t *testing.T) {
+		lexytest.Run(t, myCodec, []MyType{...}, nil)
+	}
+
+[Fuzz] performs the same checks on values created from fuzzed input,
+and also checks that encodings are ordered consistently with a comparison function.
+
+	func FuzzMyCodec(f *testing.F) {
+		lexytest.Fuzz(f, myCodec, newMyTypeFromBytes, compareMyType)
+	}
+
+[Golden] checks that encodings have not changed from those recorded in a golden file,
+protecting persisted data from accidental changes to a Codec, including upgrades of lexy itself.
+lexy uses it to lock down the encodings of all of its own Codecs.
+
+	func TestMyCodecGolden(t *testing.T) {
+		lexytest.Golden(t, myCodec, "testdata/my_codec.hex", []MyType{...}, nil)
+	}
+*/
+package lexytest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/phiryll/lexy"
+)
+
+// Run runs subtests of t checking that codec is a conforming Codec, using orderedValues,
+// which must be in strictly increasing order and are expected to be encoded in that order.
+// equal is used to compare values, and if nil, [reflect.DeepEqual] is used instead.
+//
+// For each value, Run checks that:
+//
+//	Append
+//	  - returns the same encoding every time
+//	  - does not modify the buffer's existing data
+//	Put
+//	  - writes the same encoding as Append, and returns the rest of the buffer
+//	  - does not modify the buffer beyond the value written
+//	  - panics when the buffer is 1 byte too short
+//	Get
+//	  - returns a value equal to the original value, and the rest of the buffer
+//	  - does not modify the buffer
+//	  - when the buffer is 1 byte too short, either
+//	    - panics
+//	    - OR if the value is non-zero, returns a different value and reads the entire buffer
+//
+// Run also checks that:
+//
+//   - Get panics on an empty buffer, unless the zero value of T has an empty encoding
+//   - the encodings of orderedValues are in strictly increasing order, as compared by [bytes.Compare]
+//   - if codec.RequiresTerminator() is false, that no encoding is a proper prefix of another,
+//     and that Get decodes a value followed by another encoding, leaving the other encoding unread
+//
+// Panics from codec are reported as test failures.
+// Run requires Append to return the same encoding every time for the same value,
+// so it cannot be used with Codecs like [lexy.MapOf] for maps with more than one entry.
+func Run[T any](t *testing.T, codec lexy.Codec[T], orderedValues []T, equal func(a, b T) bool) {
+	t.Helper()
+	for _, check := range newChecker(codec, equal).checks(orderedValues) {
+		t.Run(check.name, func(t *testing.T) {
+			report(t, check.check)
+		})
+	}
+}
+
+// report fails t with the error returned by check, or the value it panicked with.
+func report(t *testing.T, check func() error) {
+	t.Helper()
+	if err := recovered(check); err != nil {
+		t.Error(err)
+	}
+}
+
+// recovered returns the error returned by f, or an error describing the value f panicked with.
+//
+//nolint:nonamedreturns
+func recovered(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unexpected panic: %v", r)
+		}
+	}()
+	return f()
+}
+
+// panics returns true if f panics.
+//
+//nolint:nonamedreturns
+func panics(f func()) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+		}
+	}()
+	f()
+	return false
+}
+
+type checker[T any] struct {
+	codec lexy.Codec[T]
+	equal func(a, b T) bool
+}
+
+func newChecker[T any](codec lexy.Codec[T], equal func(a, b T) bool) checker[T] {
+	if equal == nil {
+		equal = func(a, b T) bool { return reflect.DeepEqual(a, b) }
+	}
+	return checker[T]{codec, equal}
+}
+
+// A namedCheck is a check run as a subtest with the given name.
+type namedCheck struct {
+	name  string
+	check func() error
+}
+
+// checks returns all the checks Run performs for values.
+func (c checker[T]) checks(values []T) []namedCheck {
+	checks := []namedCheck{{"get empty", c.checkGetEmpty}}
+	for i, value := range values {
+		checks = append(checks, namedCheck{fmt.Sprintf("values[%d]", i), func() error { return c.checkValue(value) }})
+	}
+	return append(checks,
+		namedCheck{"ordering", func() error { return c.checkOrdering(values) }},
+		namedCheck{"requires terminator", func() error { return c.checkTerminator(values) }},
+	)
+}
+
+func (c checker[T]) checkGetEmpty() error {
+	var zero T
+	if len(c.codec.Append(nil, zero)) == 0 {
+		return nil
+	}
+	if !panics(func() { c.codec.Get([]byte{}) }) {
+		return errors.New("Codec.Get did not panic on an empty buffer")
+	}
+	return nil
+}
+
+// fill is written to buffers to detect writes beyond an encoding.
+const fill byte = 37
+
+func (c checker[T]) checkValue(value T) error {
+	data := c.codec.Append(nil, value)
+	return errors.Join(
+		c.checkAppend(value, data),
+		c.checkPut(value, data),
+		c.checkGet(value, data),
+		c.checkShortBuf(value, data),
+	)
+}
+
+func (c checker[T]) checkAppend(value T, data []byte) error {
+	var errs []error
+	if again := c.codec.Append(nil, value); !bytes.Equal(data, again) {
+		errs = append(errs, fmt.Errorf("Codec.Append returned different encodings %X and %X for %v", data, again, value))
+	}
+	header := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	buf := c.codec.Append(slices.Clone(header), value)
+	if !bytes.Equal(header, buf[:len(header)]) {
+		errs = append(errs, fmt.Errorf("Codec.Append modified the existing buffer %X to %X", header, buf[:len(header)]))
+	}
+	if !bytes.Equal(data, buf[len(header):]) {
+		errs = append(errs, fmt.Errorf("Codec.Append to an existing buffer wrote %X, want %X", buf[len(header):], data))
+	}
+	return errors.Join(errs...)
+}
+
+func (c checker[T]) checkPut(value T, data []byte) error {
+	var errs []error
+	buf := make([]byte, len(data))
+	if rest := c.codec.Put(buf, value); len(rest) != 0 {
+		errs = append(errs, fmt.Errorf("Codec.Put returned %d unwritten bytes, want 0", len(rest)))
+	}
+	if !bytes.Equal(data, buf) {
+		errs = append(errs, fmt.Errorf("Codec.Put wrote %X, but Append wrote %X", buf, data))
+	}
+	const extra = 10
+	buf = bytes.Repeat([]byte{fill}, len(data)+extra)
+	if rest := c.codec.Put(buf, value); len(rest) != extra {
+		errs = append(errs, fmt.Errorf("Codec.Put wrote %d bytes, want %d", len(buf)-len(rest), len(data)))
+	}
+	if !bytes.Equal(bytes.Repeat([]byte{fill}, extra), buf[len(data):]) {
+		errs = append(errs, fmt.Errorf("Codec.Put modified the buffer beyond the encoding: %X", buf[len(data):]))
+	}
+	if len(data) > 0 && !panics(func() { c.codec.Put(make([]byte, len(data)+extra)[:len(data)-1], value) }) {
+		errs = append(errs, errors.New("Codec.Put did not panic on a buffer 1 byte too short"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c checker[T]) checkGet(value T, data []byte) error {
+	var errs []error
+	buf := slices.Clone(data)
+	got, rest := c.codec.Get(buf)
+	if !c.equal(value, got) {
+		errs = append(errs, fmt.Errorf("Codec.Get(%X) returned %v, want %v", data, got, value))
+	}
+	if len(rest) != 0 {
+		errs = append(errs, fmt.Errorf("Codec.Get(%X) left %d bytes unread, want 0", data, len(rest)))
+	}
+	if !bytes.Equal(data, buf) {
+		errs = append(errs, fmt.Errorf("Codec.Get modified the buffer %X to %X", data, buf))
+	}
+	return errors.Join(errs...)
+}
+
+func (c checker[T]) checkShortBuf(value T, data []byte) error {
+	if len(data) <= 1 {
+		// Shortening 1 byte to 0 is the same as checkGetEmpty.
+		return nil
+	}
+	buf := slices.Clone(data[:len(data)-1])
+	var got T
+	var rest []byte
+	if panics(func() { got, rest = c.codec.Get(buf) }) {
+		return nil
+	}
+	var errs []error
+	if len(rest) != 0 {
+		errs = append(errs, fmt.Errorf("Codec.Get(%X) of a short buffer left %d bytes unread, want 0", buf, len(rest)))
+	}
+	// Both might be the zero value by chance.
+	if !reflect.ValueOf(&value).Elem().IsZero() && c.equal(value, got) {
+		errs = append(errs, fmt.Errorf("Codec.Get(%X) of a short buffer returned the full value %v", buf, value))
+	}
+	return errors.Join(errs...)
+}
+
+func (c checker[T]) encodeAll(values []T) [][]byte {
+	encodings := make([][]byte, len(values))
+	for i, value := range values {
+		encodings[i] = c.codec.Append(nil, value)
+	}
+	return encodings
+}
+
+func (c checker[T]) checkOrdering(values []T) error {
+	var errs []error
+	encodings := c.encodeAll(values)
+	for i := 1; i < len(encodings); i++ {
+		if bytes.Compare(encodings[i-1], encodings[i]) >= 0 {
+			errs = append(errs, fmt.Errorf("encodings of values[%d] and values[%d] are not in increasing order: %X, %X",
+				i-1, i, encodings[i-1], encodings[i]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c checker[T]) checkTerminator(values []T) error {
+	if c.codec.RequiresTerminator() {
+		return nil
+	}
+	var errs []error
+	encodings := c.encodeAll(values)
+	for i, a := range encodings {
+		for j, b := range encodings {
+			if len(a) < len(b) && bytes.HasPrefix(b, a) {
+				errs = append(errs, fmt.Errorf(
+					"RequiresTerminator is false, but the encoding of values[%d] is a prefix of values[%d]: %X, %X",
+					i, j, a, b))
+			}
+		}
+	}
+	for i, a := range encodings {
+		// Followed by itself and by the next encoding.
+		for _, b := range [][]byte{a, encodings[(i+1)%len(encodings)]} {
+			got, rest := c.codec.Get(append(slices.Clone(a), b...))
+			if !c.equal(values[i], got) || !bytes.Equal(b, rest) {
+				errs = append(errs, fmt.Errorf(
+					"RequiresTerminator is false, but Codec.Get(%X) followed by %X returned %v and %X",
+					a, b, got, rest))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
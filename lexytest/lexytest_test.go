@@ -0,0 +1,187 @@
+package lexytest_test
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+	"github.com/phiryll/lexy/lexytest"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	lexytest.Run(t, lexy.Int32(), []int32{math.MinInt32, -1, 0, 1, math.MaxInt32}, nil)
+	lexytest.Run(t, lexy.String(), []string{"", "\x00", "a", "ab", "b"}, nil)
+	lexytest.Run(t, lexy.Terminate(lexy.String()), []string{"", "\x00", "a", "ab", "b"}, nil)
+	lexytest.Run(t, lexy.SliceOf(lexy.String()), [][]string{nil, {}, {""}, {"", "a"}, {"a"}}, nil)
+	lexytest.Run(t, lexy.PointerTo(lexy.Uint8()), []*uint8{nil, new(uint8)}, func(a, b *uint8) bool {
+		return (a == nil) == (b == nil) && (a == nil || *a == *b)
+	})
+	lexytest.Run(t, lexy.Empty[int](), []int{0}, nil)
+}
+
+// fakeCodec is a Codec for uint16 like lexy.Uint16(), but with configurable bugs.
+type fakeCodec struct {
+	lexy.Codec[uint16]
+	modifyAppend  bool
+	skipPutCheck  bool
+	getWrongValue bool
+	noTerminator  bool
+}
+
+func (c fakeCodec) Append(buf []byte, value uint16) []byte {
+	if c.modifyAppend && len(buf) > 0 {
+		buf[0]++
+	}
+	return c.Codec.Append(buf, value)
+}
+
+func (c fakeCodec) Put(buf []byte, value uint16) []byte {
+	if c.skipPutCheck && len(buf) < 2 {
+		return buf[len(buf):]
+	}
+	return c.Codec.Put(buf, value)
+}
+
+func (c fakeCodec) Get(buf []byte) (uint16, []byte) {
+	value, buf := c.Codec.Get(buf)
+	if c.getWrongValue {
+		value++
+	}
+	return value, buf
+}
+
+func (c fakeCodec) RequiresTerminator() bool {
+	return c.noTerminator || c.Codec.RequiresTerminator()
+}
+
+func TestCheckFailures(t *testing.T) {
+	t.Parallel()
+	values := []uint16{0, 1, math.MaxUint16}
+	assert.Empty(t, lexytest.TestingCheck[uint16](fakeCodec{Codec: lexy.Uint16()}, values, nil))
+
+	errs := lexytest.TestingCheck[uint16](fakeCodec{Codec: lexy.Uint16(), modifyAppend: true}, values, nil)
+	require.Contains(t, errs, "values[0]")
+	assert.ErrorContains(t, errs["values[0]"], "Codec.Append modified the existing buffer")
+
+	errs = lexytest.TestingCheck[uint16](fakeCodec{Codec: lexy.Uint16(), skipPutCheck: true}, values, nil)
+	require.Contains(t, errs, "values[1]")
+	assert.ErrorContains(t, errs["values[1]"], "Codec.Put did not panic on a buffer 1 byte too short")
+
+	errs = lexytest.TestingCheck[uint16](fakeCodec{Codec: lexy.Uint16(), getWrongValue: true}, values, nil)
+	require.Contains(t, errs, "values[2]")
+	assert.ErrorContains(t, errs["values[2]"], "Codec.Get(FFFF) returned 0, want 65535")
+	assert.ErrorContains(t, errs["requires terminator"], "Codec.Get(0000) followed by 0000 returned 1 and 0000")
+
+	errs = lexytest.TestingCheck(lexy.Uint16(), []uint16{0, 2, 1}, nil)
+	assert.Len(t, errs, 1)
+	assert.ErrorContains(t, errs["ordering"], "encodings of values[1] and values[2] are not in increasing order")
+
+	errs = lexytest.TestingCheck(lexy.Uint16(), []uint16{0, 0}, nil)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs, "ordering")
+}
+
+// lyingCodec claims its wrapped Codec does not require escaping.
+type lyingCodec[T any] struct {
+	lexy.Codec[T]
+}
+
+func (lyingCodec[T]) RequiresTerminator() bool {
+	return false
+}
+
+func TestCheckRequiresTerminator(t *testing.T) {
+	t.Parallel()
+	errs := lexytest.TestingCheck[string](lyingCodec[string]{lexy.String()}, []string{"", "a", "ab"}, nil)
+	assert.Len(t, errs, 1)
+	require.Contains(t, errs, "requires terminator")
+	assert.ErrorContains(t, errs["requires terminator"], "the encoding of values[1] is a prefix of values[2]: 61, 6162")
+
+	// Terminated encodings are never prefixes.
+	errs = lexytest.TestingCheck[string](lyingCodec[string]{lexy.Terminate(lexy.String())}, []string{"", "a", "ab"}, nil)
+	assert.Empty(t, errs)
+}
+
+func TestCheckPanics(t *testing.T) {
+	t.Parallel()
+	errs := lexytest.TestingCheck(lexy.Enum("a", "b"), []string{"a", "c"}, nil)
+	require.Contains(t, errs, "values[1]")
+	assert.ErrorContains(t, errs["values[1]"], "unexpected panic")
+}
+
+// Generators for fuzzing.
+
+func genUint64(data []byte) uint64 {
+	var value uint64
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+	return value
+}
+
+// genStrings splits data at each 0xFF byte.
+func genStrings(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	return strings.Split(string(data), "\xFF")
+}
+
+func FuzzUint64(f *testing.F) {
+	lexytest.Fuzz(f, lexy.Uint64(), genUint64, cmp.Compare[uint64])
+}
+
+func FuzzNegString(f *testing.F) {
+	lexytest.Fuzz(f, lexy.Negate(lexy.String()), func(data []byte) string { return string(data) },
+		func(a, b string) int { return strings.Compare(b, a) })
+}
+
+func FuzzSliceOfString(f *testing.F) {
+	// nil is ordered first.
+	cmpStrings := func(a, b []string) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		default:
+			return slices.Compare(a, b)
+		}
+	}
+	f.Add([]byte("a\xFFb"), []byte("a\xFF"))
+	lexytest.Fuzz(f, lexy.SliceOf(lexy.String()), genStrings, cmpStrings)
+}
+
+func TestFuzzCheck(t *testing.T) {
+	t.Parallel()
+	check := lexytest.TestingFuzzCheck(lexy.Uint64(), genUint64, cmp.Compare[uint64])
+	require.NoError(t, check([]byte{1}, []byte{2}))
+
+	// Wrong comparison.
+	check = lexytest.TestingFuzzCheck(lexy.Negate(lexy.Uint64()), genUint64, cmp.Compare[uint64])
+	require.ErrorContains(t, check([]byte{1}, []byte{2}),
+		"cmp(1, 2) = -1, but bytes.Compare(FFFFFFFFFFFFFFFE, FFFFFFFFFFFFFFFD) = 1")
+	require.NoError(t, check([]byte{1}, []byte{1}))
+
+	// Concatenated encodings of a Codec requiring a terminator are terminated.
+	genString := func(data []byte) string { return string(data) }
+	check = lexytest.TestingFuzzCheck(lexy.String(), genString, strings.Compare)
+	require.NoError(t, check([]byte("a"), []byte("\x00b")))
+
+	check = lexytest.TestingFuzzCheck[string](lyingCodec[string]{lexy.String()}, genString, strings.Compare)
+	require.ErrorContains(t, check([]byte("a"), []byte("b")),
+		"concatenated encodings 6162 decoded to ab and  with  unread, want a and b")
+
+	// Panics are errors.
+	check = lexytest.TestingFuzzCheck(lexy.Enum[uint64](0, 1), genUint64, cmp.Compare[uint64])
+	require.ErrorContains(t, check([]byte{1}, []byte{2}), "unexpected panic")
+}
@@ -59,6 +59,36 @@ func (mapCodec[K, V]) RequiresTerminator() bool {
 	return true
 }
 
+func (c mapCodec[K, V]) Describe() Description {
+	return Description{
+		Kind:     "map",
+		Key:      describeRef(c.keyCodec),
+		Value:    describeRef(c.valueCodec),
+		NilsLast: isNilsLast(c.prefix),
+	}
+}
+
+func (c mapCodec[K, V]) format(f *formatState, buf []byte) []byte {
+	done, buf := formatNillable(f, c.prefix, buf)
+	if done {
+		return buf
+	}
+	f.write("{")
+	for i := 0; f.more(buf); i++ {
+		if i > 0 {
+			f.write(", ")
+		}
+		buf = formatPart(f, c.keyCodec, buf)
+		if f.out.failed {
+			break
+		}
+		f.write(": ")
+		buf = formatPart(f, c.valueCodec, buf)
+	}
+	f.write("}")
+	return buf
+}
+
 //lint:ignore U1000 this is actually used
 func (c mapCodec[K, V]) nilsLast() Codec[map[K]V] {
 	return mapCodec[K, V]{c.keyCodec, c.valueCodec, PrefixNilsLast}
@@ -0,0 +1,111 @@
+package lexy
+
+// naturalCodec is the Codec for strings in natural order,
+// where runs of ASCII digits are ordered by their numeric values.
+// For example, "file2" < "file10", which is not true for stringCodec.
+// This Codec requires escaping, and is wrapped with Terminate when returned by NaturalString.
+//
+// A string is split into maximal runs of ASCII digits and single non-digit bytes.
+// A non-digit byte is encoded as itself.
+// A run of digits is encoded as:
+//
+//	'0' (digitsMarker)
+//	number of digits after any leading zeros, using the order-preserving varint encoding in varint.go
+//	the digits after any leading zeros, as-is
+//	number of leading zeros, using the order-preserving varint encoding in varint.go
+//
+// Because digits are never encoded as themselves, the marker cannot be mistaken for data,
+// and a run of digits is ordered relative to a non-digit byte the same way its first digit would be.
+// Numbers with fewer significant digits are smaller, and numbers with the same number of significant digits
+// are ordered by their digits. Arbitrarily long runs of digits are handled this way.
+// When two numbers have the same value, the one with fewer leading zeros is ordered first,
+// before comparing anything following them. For example, "a1" < "a1b" < "a01" < "a001".
+//
+// The original string can always be reconstructed exactly from its encoding.
+// Get will fully consume its argument buffer.
+type naturalCodec struct{}
+
+const digitsMarker byte = '0'
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// digitRun returns the end of the run of digits starting at value[start], and its number of leading zeros.
+func digitRun(value string, start int) (end, numZeros int) {
+	end = start
+	for end < len(value) && value[end] == '0' {
+		end++
+	}
+	numZeros = end - start
+	for end < len(value) && isDigit(value[end]) {
+		end++
+	}
+	return end, numZeros
+}
+
+func (naturalCodec) Append(buf []byte, value string) []byte {
+	for i := 0; i < len(value); {
+		if !isDigit(value[i]) {
+			buf = append(buf, value[i])
+			i++
+			continue
+		}
+		end, numZeros := digitRun(value, i)
+		start := i + numZeros
+		buf = append(buf, digitsMarker)
+		buf = appendUvarint(buf, uint64(end-start))
+		buf = append(buf, value[start:end]...)
+		buf = appendUvarint(buf, uint64(numZeros))
+		i = end
+	}
+	return buf
+}
+
+func (naturalCodec) Put(buf []byte, value string) []byte {
+	for i := 0; i < len(value); {
+		if !isDigit(value[i]) {
+			buf[0] = value[i]
+			buf = buf[1:]
+			i++
+			continue
+		}
+		end, numZeros := digitRun(value, i)
+		start := i + numZeros
+		buf[0] = digitsMarker
+		buf = putUvarint(buf[1:], uint64(end-start))
+		buf = copyAll(buf, []byte(value[start:end]))
+		buf = putUvarint(buf, uint64(numZeros))
+		i = end
+	}
+	return buf
+}
+
+func (naturalCodec) Get(buf []byte) (string, []byte) {
+	value := make([]byte, 0, len(buf))
+	for i := 0; i < len(buf); {
+		if buf[i] != digitsMarker {
+			value = append(value, buf[i])
+			i++
+			continue
+		}
+		numDigits, rest := getUvarint(buf[i+1:])
+		_ = rest[:numDigits] // check that we have enough
+		digits := rest[:numDigits]
+		numZeros, rest := getUvarint(rest[numDigits:])
+		for range numZeros {
+			value = append(value, '0')
+		}
+		value = append(value, digits...)
+		i = len(buf) - len(rest)
+	}
+	return string(value), buf[len(buf):]
+}
+
+func (naturalCodec) RequiresTerminator() bool {
+	return true
+}
+
+func (naturalCodec) Describe() Description {
+	return Description{Kind: "natural_string"}
+}
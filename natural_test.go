@@ -0,0 +1,151 @@
+package lexy_test
+
+import (
+	"cmp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/phiryll/lexy"
+)
+
+// A natural string token, either a single non-digit byte or a run of digits.
+type naturalToken struct {
+	b        byte
+	isNumber bool
+	digits   string // significant digits, if isNumber
+	zeros    int    // number of leading zeros, if isNumber
+}
+
+func naturalTokens(s string) []naturalToken {
+	var tokens []naturalToken
+	for i := 0; i < len(s); {
+		if s[i] < '0' || s[i] > '9' {
+			tokens = append(tokens, naturalToken{b: s[i]})
+			i++
+			continue
+		}
+		start := i
+		for i < len(s) && '0' <= s[i] && s[i] <= '9' {
+			i++
+		}
+		run := s[start:i]
+		digits := strings.TrimLeft(run, "0")
+		tokens = append(tokens, naturalToken{'0', true, digits, len(run) - len(digits)})
+	}
+	return tokens
+}
+
+// Implements the ordering semantics of NaturalString without encoding.
+func cmpNatural(a, b string) int {
+	aTokens := naturalTokens(a)
+	bTokens := naturalTokens(b)
+	for i := range min(len(aTokens), len(bTokens)) {
+		x, y := aTokens[i], bTokens[i]
+		if c := cmp.Compare(x.b, y.b); c != 0 {
+			return c
+		}
+		if !x.isNumber {
+			continue
+		}
+		if c := cmp.Compare(len(x.digits), len(y.digits)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(x.digits, y.digits); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(x.zeros, y.zeros); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(aTokens), len(bTokens))
+}
+
+func TestNaturalString(t *testing.T) {
+	t.Parallel()
+	codec := lexy.NaturalString()
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[string]{
+		{"empty", "", []byte{term}},
+		{"abc", "abc", []byte{'a', 'b', 'c', term}},
+		// Varint bytes 0x00 and 0x01 are escaped by the terminator.
+		{"0", "0", []byte{'0', esc, 0x00, esc, 0x01, esc, 0x01, term}},
+		{"7", "7", []byte{'0', esc, 0x01, esc, 0x01, '7', esc, 0x00, term}},
+		{"a12b", "a12b", []byte{'a', '0', esc, 0x01, 0x02, '1', '2', esc, 0x00, 'b', term}},
+		{"a007", "a007", []byte{'a', '0', esc, 0x01, esc, 0x01, '7', esc, 0x01, 0x02, term}},
+		{"\\x00\\x01", "\x00\x01", []byte{esc, 0x00, esc, 0x01, term}},
+	})
+}
+
+func TestNaturalStringRoundTrip(t *testing.T) {
+	t.Parallel()
+	codec := lexy.NaturalString()
+	testCodec(t, codec, fillTestData(codec, []testCase[string]{
+		{"file10.txt", "file10.txt", nil},
+		{"zeros", "000", nil},
+		{"v1.02.0003-rc0", "v1.02.0003-rc0", nil},
+		{"long digits", strings.Repeat("9", 300) + "x" + strings.Repeat("0", 300), nil},
+		{"non-ascii digits", "٣file٤", nil},
+		{"invalid utf8", "\xFF1\xFE", nil},
+	}))
+}
+
+func TestNaturalStringOrdering(t *testing.T) {
+	t.Parallel()
+	testCases := []testCase[string]{
+		{"empty", "", nil},
+		{"!", "!", nil},
+		{"0", "0", nil},
+		{"00", "00", nil},
+		{"1", "1", nil},
+		{"1a", "1a", nil},
+		{"01", "01", nil},
+		{"2", "2", nil},
+		{"10", "10", nil},
+		{"99999999999999999999", "99999999999999999999", nil},
+		{"100000000000000000000", "100000000000000000000", nil},
+		{":", ":", nil},
+		{"file", "file", nil},
+		{"file-1", "file-1", nil},
+		{"file2", "file2", nil},
+		{"file2.txt", "file2.txt", nil},
+		{"file10", "file10", nil},
+		{"file10a", "file10a", nil},
+		{"file11", "file11", nil},
+		{"filea", "filea", nil},
+	}
+	for i := range testCases[1:] {
+		assert.Equal(t, -1, cmpNatural(testCases[i].value, testCases[i+1].value))
+	}
+	testOrdering(t, lexy.NaturalString(), testCases)
+}
+
+func TestNaturalStringComposes(t *testing.T) {
+	t.Parallel()
+	negated := lexy.Negate(lexy.NaturalString())
+	testOrdering(t, negated, []testCase[string]{
+		{"file10", "file10", nil},
+		{"file2", "file2", nil},
+		{"file", "file", nil},
+	})
+	codec := lexy.SliceOf(lexy.NaturalString())
+	testCodec(t, codec, fillTestData(codec, []testCase[[]string]{
+		{"slice", []string{"a10", "", "0\x00", "b2"}, nil},
+	}))
+	testOrdering(t, codec, []testCase[[]string]{
+		{"[a2]", []string{"a2"}, nil},
+		{"[a2, b]", []string{"a2", "b"}, nil},
+		{"[a10]", []string{"a10"}, nil},
+	})
+}
+
+//nolint:paralleltest // allocation counts are unreliable when run in parallel
+func TestNaturalStringPutNoAlloc(t *testing.T) {
+	codec := lexy.NaturalString()
+	buf := make([]byte, 128)
+	allocs := testing.AllocsPerRun(100, func() {
+		codec.Put(buf, "chapter 0012, section 3, with a name long enough to escape a small stack buffer")
+	})
+	assert.Zero(t, allocs)
+}
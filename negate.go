@@ -49,6 +49,19 @@ func (negateCodec[T]) RequiresTerminator() bool {
 	return false
 }
 
+func (c negateCodec[T]) Describe() Description {
+	// Negating twice flips the bits back.
+	d := Describe(c.codec)
+	d.Negated = !d.Negated
+	return d
+}
+
+func (c negateCodec[T]) format(f *formatState, buf []byte) []byte {
+	negated := negCopy(buf)
+	temp := formatPart(f.within(buf, negated, true), c.codec, negated)
+	return buf[len(buf)-len(temp):]
+}
+
 // negateEscapeCodec negates codec which requires escaping, reversing the ordering of its encoding.
 //
 // Every encoding will be greater than any prefix of that encoding (definition of lexicographical ordering).
@@ -109,6 +122,20 @@ func (negateEscapeCodec[T]) RequiresTerminator() bool {
 	return false
 }
 
+func (c negateEscapeCodec[T]) Describe() Description {
+	// The delegate requires escaping, so it is neither negated nor terminated itself.
+	d := Describe(c.codec)
+	d.Terminated = true
+	d.Negated = true
+	return d
+}
+
+func (c negateEscapeCodec[T]) format(f *formatState, buf []byte) []byte {
+	encodedValue, buf := negTermGet(buf)
+	formatPart(f.within(buf, encodedValue, false), c.codec, encodedValue)
+	return buf
+}
+
 // negTerm is exactly the same as term, except that it negates every byte written.
 func negTerm(buf []byte, n int) {
 	// Going backwards ensures that every byte is copied at most once.
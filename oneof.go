@@ -0,0 +1,132 @@
+package lexy
+
+import "fmt"
+
+// Variant is one of the alternatives of a Codec created by [OneOf].
+//
+// Tag is written before the encoding of a value by Codec.
+// A value is encoded with this Variant if Matches returns true for it,
+// and it is not matched by any earlier Variant passed to OneOf.
+type Variant[T any] struct {
+	Tag     byte
+	Matches func(T) bool
+	Codec   Codec[T]
+}
+
+// VariantOf returns a [Variant] of T matching values whose dynamic type is V, encoded with codec.
+// T is typically an interface type implemented by V.
+// The Codec of the returned Variant will panic if V cannot be converted to T when decoding.
+func VariantOf[T, V any](tag byte, codec Codec[V]) Variant[T] {
+	return Variant[T]{
+		tag,
+		func(value T) bool {
+			_, ok := any(value).(V)
+			return ok
+		},
+		variantCodec[T, V]{codec},
+	}
+}
+
+// variantCodec is the Codec used by VariantOf,
+// converting between T and V with type assertions.
+type variantCodec[T, V any] struct {
+	codec Codec[V]
+}
+
+func (c variantCodec[T, V]) Append(buf []byte, value T) []byte {
+	//nolint:forcetypeassert
+	return c.codec.Append(buf, any(value).(V))
+}
+
+func (c variantCodec[T, V]) Put(buf []byte, value T) []byte {
+	//nolint:forcetypeassert
+	return c.codec.Put(buf, any(value).(V))
+}
+
+func (c variantCodec[T, V]) Get(buf []byte) (T, []byte) {
+	value, buf := c.codec.Get(buf)
+	//nolint:forcetypeassert
+	return any(value).(T), buf
+}
+
+func (c variantCodec[T, V]) RequiresTerminator() bool {
+	return c.codec.RequiresTerminator()
+}
+
+func (c variantCodec[T, V]) Describe() Description {
+	return Describe(c.codec)
+}
+
+func (c variantCodec[T, V]) format(f *formatState, buf []byte) []byte {
+	return formatPart(f, c.codec, buf)
+}
+
+// oneOfCodec is the Codec for values which may be any one of several variants.
+// A value is encoded as the tag of the first matching variant, followed by its encoding by that variant's Codec.
+// Values are ordered first by tag, and then by the order of the variant's Codec.
+//
+// The tag determines how the rest of the encoding is decoded,
+// so this Codec requires escaping if and only if any variant's Codec does.
+type oneOfCodec[T any] struct {
+	variants []Variant[T]
+}
+
+func (c oneOfCodec[T]) match(value T) *Variant[T] {
+	for i := range c.variants {
+		if c.variants[i].Matches(value) {
+			return &c.variants[i]
+		}
+	}
+	panic(noVariantError{value})
+}
+
+func (c oneOfCodec[T]) Append(buf []byte, value T) []byte {
+	v := c.match(value)
+	buf = append(buf, v.Tag)
+	return v.Codec.Append(buf, value)
+}
+
+func (c oneOfCodec[T]) Put(buf []byte, value T) []byte {
+	v := c.match(value)
+	buf[0] = v.Tag
+	return v.Codec.Put(buf[1:], value)
+}
+
+func (c oneOfCodec[T]) Get(buf []byte) (T, []byte) {
+	tag := buf[0]
+	for i := range c.variants {
+		if c.variants[i].Tag == tag {
+			return c.variants[i].Codec.Get(buf[1:])
+		}
+	}
+	panic(unknownTagError{tag})
+}
+
+func (c oneOfCodec[T]) RequiresTerminator() bool {
+	for i := range c.variants {
+		if c.variants[i].Codec.RequiresTerminator() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c oneOfCodec[T]) Describe() Description {
+	d := Description{Kind: "one_of"}
+	for i := range c.variants {
+		d.Params = append(d.Params, c.variants[i].Tag)
+		d.Elems = append(d.Elems, Describe(c.variants[i].Codec))
+	}
+	return d
+}
+
+func (c oneOfCodec[T]) format(f *formatState, buf []byte) []byte {
+	tag := buf[0]
+	for i := range c.variants {
+		if c.variants[i].Tag == tag {
+			fmt.Fprintf(f.out, "%d:", tag)
+			return formatPart(f, c.variants[i].Codec, buf[1:])
+		}
+	}
+	panic(unknownTagError{tag})
+}
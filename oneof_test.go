@@ -0,0 +1,120 @@
+package lexy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/phiryll/lexy"
+)
+
+type principal interface {
+	isPrincipal()
+}
+
+type (
+	userID    uint32
+	groupName string
+	serviceID int8
+)
+
+func (userID) isPrincipal()    {}
+func (groupName) isPrincipal() {}
+func (serviceID) isPrincipal() {}
+
+func principalCodec() lexy.Codec[principal] {
+	isNil := func(p principal) bool { return p == nil }
+	return lexy.OneOf(
+		lexy.Variant[principal]{0x00, isNil, lexy.Empty[principal]()},
+		lexy.VariantOf[principal](0x10, lexy.CastUint32[userID]()),
+		lexy.VariantOf[principal](0x20, lexy.Terminate(lexy.CastString[groupName]())),
+		lexy.VariantOf[principal](0x30, lexy.CastInt8[serviceID]()),
+	)
+}
+
+func TestOneOf(t *testing.T) {
+	t.Parallel()
+	codec := principalCodec()
+	// Because of the Empty Codec for nil.
+	assert.True(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[principal]{
+		{"nil", nil, []byte{0x00}},
+		{"user 0", userID(0), []byte{0x10, 0x00, 0x00, 0x00, 0x00}},
+		{"user 258", userID(258), []byte{0x10, 0x00, 0x00, 0x01, 0x02}},
+		{"group empty", groupName(""), []byte{0x20, term}},
+		{"group abc", groupName("abc"), []byte{0x20, 'a', 'b', 'c', term}},
+		{"service -1", serviceID(-1), []byte{0x30, 0x7F}},
+	})
+}
+
+func TestOneOfOrdering(t *testing.T) {
+	t.Parallel()
+	testOrdering(t, principalCodec(), []testCase[principal]{
+		{"nil", nil, nil},
+		{"user 0", userID(0), nil},
+		{"user 1", userID(1), nil},
+		{"user max", userID(0xFFFFFFFF), nil},
+		{"group empty", groupName(""), nil},
+		{"group a", groupName("a"), nil},
+		{"group ab", groupName("ab"), nil},
+		{"group b", groupName("b"), nil},
+		{"service min", serviceID(-128), nil},
+		{"service 0", serviceID(0), nil},
+		{"service max", serviceID(127), nil},
+	})
+}
+
+func TestOneOfPredicate(t *testing.T) {
+	t.Parallel()
+	isNegative := func(x int16) bool { return x < 0 }
+	always := func(int16) bool { return true }
+	// Negative values ordered last, each group ordered by magnitude.
+	codec := lexy.OneOf(
+		lexy.Variant[int16]{0x01, isNegative, lexy.Int16()},
+		lexy.Variant[int16]{0x00, always, lexy.Int16()},
+	)
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[int16]{
+		{"0", 0, []byte{0x00, 0x80, 0x00}},
+		{"-1", -1, []byte{0x01, 0x7F, 0xFF}},
+	})
+	testOrdering(t, codec, []testCase[int16]{
+		{"0", 0, nil},
+		{"1", 1, nil},
+		{"max", 0x7FFF, nil},
+		{"min", -0x8000, nil},
+		{"-1", -1, nil},
+	})
+}
+
+func TestOneOfTerminated(t *testing.T) {
+	t.Parallel()
+	testCodec(t, lexy.SliceOf(principalCodec()), []testCase[[]principal]{
+		{"mixed", []principal{groupName("a"), nil, userID(1)}, []byte{
+			pNonNil,
+			0x20, 'a', esc, term, term,
+			esc, 0x00, term,
+			0x10, esc, 0x00, esc, 0x00, esc, 0x00, esc, 0x01, term,
+		}},
+	})
+}
+
+func TestOneOfPanics(t *testing.T) {
+	t.Parallel()
+	assert.PanicsWithError(t, "duplicate variant tag 0x10", func() {
+		lexy.OneOf(
+			lexy.VariantOf[principal](0x10, lexy.CastUint32[userID]()),
+			lexy.VariantOf[principal](0x10, lexy.CastInt8[serviceID]()),
+		)
+	})
+	codec := lexy.OneOf(lexy.VariantOf[principal](0x10, lexy.CastUint32[userID]()))
+	assert.PanicsWithError(t, "no variant matches value of type lexy_test.groupName", func() {
+		codec.Append(nil, groupName("a"))
+	})
+	assert.PanicsWithError(t, "no variant matches value of type <nil>", func() {
+		codec.Append(nil, nil)
+	})
+	assert.PanicsWithError(t, "unknown variant tag 0x20", func() {
+		codec.Get([]byte{0x20, 'a'})
+	})
+}
@@ -0,0 +1,74 @@
+package lexy
+
+// Optional is a value of type T which may be absent, without the allocation and pointer semantics of *T.
+// If Present is false, Value should be the zero value of T.
+type Optional[T any] struct {
+	Value   T
+	Present bool
+}
+
+// Some returns a present Optional containing value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value, true}
+}
+
+// optionalCodec is the Codec for Optionals, using elemCodec to encode and decode the value if present.
+// An Optional is encoded as:
+//   - if absent, prefixNilFirst/Last
+//   - if present, prefixNonNil followed by its encoded value
+type optionalCodec[E any] struct {
+	elemCodec Codec[E]
+	prefix    Prefix
+}
+
+func (c optionalCodec[E]) Append(buf []byte, value Optional[E]) []byte {
+	done, buf := c.prefix.Append(buf, !value.Present)
+	if done {
+		return buf
+	}
+	return c.elemCodec.Append(buf, value.Value)
+}
+
+func (c optionalCodec[E]) Put(buf []byte, value Optional[E]) []byte {
+	done, buf := c.prefix.Put(buf, !value.Present)
+	if done {
+		return buf
+	}
+	return c.elemCodec.Put(buf, value.Value)
+}
+
+func (c optionalCodec[E]) Get(buf []byte) (Optional[E], []byte) {
+	done, buf := c.prefix.Get(buf)
+	if done {
+		return Optional[E]{}, buf
+	}
+	value, buf := c.elemCodec.Get(buf)
+	return Optional[E]{value, true}, buf
+}
+
+func (c optionalCodec[E]) RequiresTerminator() bool {
+	// An encoded absent value cannot be a prefix of an encoded present value,
+	// so this Codec requires escaping if and only if the element Codec does.
+	return c.elemCodec.RequiresTerminator()
+}
+
+func (c optionalCodec[E]) Describe() Description {
+	return Description{Kind: "optional", Elem: describeRef(c.elemCodec), NilsLast: isNilsLast(c.prefix)}
+}
+
+func (c optionalCodec[E]) format(f *formatState, buf []byte) []byte {
+	done, buf := c.prefix.Get(buf)
+	if done {
+		f.write("None")
+		return buf
+	}
+	f.write("Some(")
+	buf = formatPart(f, c.elemCodec, buf)
+	f.write(")")
+	return buf
+}
+
+//lint:ignore U1000 this is actually used
+func (c optionalCodec[E]) nilsLast() Codec[Optional[E]] {
+	return optionalCodec[E]{c.elemCodec, PrefixNilsLast}
+}
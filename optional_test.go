@@ -0,0 +1,89 @@
+package lexy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/phiryll/lexy"
+)
+
+func TestOptionalInt32(t *testing.T) {
+	t.Parallel()
+	codec := lexy.OptionalOf(lexy.Int32())
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.Optional[int32]]{
+		{"absent", lexy.Optional[int32]{}, []byte{pNilFirst}},
+		{"0", lexy.Some(int32(0)), []byte{pNonNil, 0x80, 0x00, 0x00, 0x00}},
+		{"-1", lexy.Some(int32(-1)), []byte{pNonNil, 0x7F, 0xFF, 0xFF, 0xFF}},
+	})
+	testOrdering(t, codec, []testCase[lexy.Optional[int32]]{
+		{"absent", lexy.Optional[int32]{}, nil},
+		{"min", lexy.Some(int32(-0x80000000)), nil},
+		{"-1", lexy.Some(int32(-1)), nil},
+		{"0", lexy.Some(int32(0)), nil},
+		{"max", lexy.Some(int32(0x7FFFFFFF)), nil},
+	})
+}
+
+func TestOptionalString(t *testing.T) {
+	t.Parallel()
+	codec := lexy.OptionalOf(lexy.String())
+	assert.True(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.Optional[string]]{
+		{"absent", lexy.Optional[string]{}, []byte{pNilFirst}},
+		{"empty", lexy.Some(""), []byte{pNonNil}},
+		{"abc", lexy.Some("abc"), []byte{pNonNil, 'a', 'b', 'c'}},
+	})
+}
+
+func TestOptionalOptional(t *testing.T) {
+	t.Parallel()
+	codec := lexy.OptionalOf(lexy.OptionalOf(lexy.Uint8()))
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.Optional[lexy.Optional[uint8]]]{
+		{"absent", lexy.Optional[lexy.Optional[uint8]]{}, []byte{pNilFirst}},
+		{"some absent", lexy.Some(lexy.Optional[uint8]{}), []byte{pNonNil, pNilFirst}},
+		{"some some 5", lexy.Some(lexy.Some(uint8(5))), []byte{pNonNil, pNonNil, 0x05}},
+	})
+}
+
+func TestOptionalNilsLast(t *testing.T) {
+	t.Parallel()
+	codec := lexy.NilsLast(lexy.OptionalOf(lexy.String()))
+	assert.True(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.Optional[string]]{
+		{"absent", lexy.Optional[string]{}, []byte{pNilLast}},
+		{"abc", lexy.Some("abc"), []byte{pNonNil, 'a', 'b', 'c'}},
+	})
+	testOrdering(t, codec, []testCase[lexy.Optional[string]]{
+		{"empty", lexy.Some(""), nil},
+		{"abc", lexy.Some("abc"), nil},
+		{"xyz", lexy.Some("xyz"), nil},
+		{"absent", lexy.Optional[string]{}, nil},
+	})
+}
+
+func TestOptionalInStruct(t *testing.T) {
+	t.Parallel()
+	// Optionals work within terminated and negated Codecs like any other value.
+	codec := lexy.SliceOf(lexy.Negate(lexy.OptionalOf(lexy.String())))
+	testOrdering(t, codec, []testCase[[]lexy.Optional[string]]{
+		{"[]", []lexy.Optional[string]{}, nil},
+		{"[xyz]", []lexy.Optional[string]{lexy.Some("xyz")}, nil},
+		{"[abc]", []lexy.Optional[string]{lexy.Some("abc")}, nil},
+		{"[absent]", []lexy.Optional[string]{{}}, nil},
+	})
+}
+
+//nolint:paralleltest // allocation counts are unreliable when run in parallel
+func TestOptionalGetNoAlloc(t *testing.T) {
+	codec := lexy.OptionalOf(lexy.Int64())
+	present := codec.Append(nil, lexy.Some(int64(12345)))
+	absent := codec.Append(nil, lexy.Optional[int64]{})
+	allocs := testing.AllocsPerRun(100, func() {
+		codec.Get(present)
+		codec.Get(absent)
+	})
+	assert.Zero(t, allocs)
+}
@@ -40,6 +40,19 @@ func (c pointerCodec[E]) RequiresTerminator() bool {
 	return c.elemCodec.RequiresTerminator()
 }
 
+func (c pointerCodec[E]) Describe() Description {
+	return Description{Kind: "pointer", Elem: describeRef(c.elemCodec), NilsLast: isNilsLast(c.prefix)}
+}
+
+func (c pointerCodec[E]) format(f *formatState, buf []byte) []byte {
+	done, buf := formatNillable(f, c.prefix, buf)
+	if done {
+		return buf
+	}
+	f.write("&")
+	return formatPart(f, c.elemCodec, buf)
+}
+
 //lint:ignore U1000 this is actually used
 func (c pointerCodec[E]) nilsLast() Codec[*E] {
 	return pointerCodec[E]{c.elemCodec, PrefixNilsLast}
@@ -0,0 +1,394 @@
+package lexy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseSchema returns a Codec described by schema, which encodes values of the types listed below as any.
+// ParseSchema returns an error if schema is not valid.
+//
+// A schema is either the name of a Codec, or the name of a Codec-returning function with arguments,
+// which are themselves schemas, separated by commas and enclosed in parentheses.
+// Whitespace between names and punctuation is ignored. For example:
+//
+//	tuple(uint32, desc(time), terminated(string), slice(int64))
+//
+// These names describe the Codec returned by the lexy function of the same meaning, and the type of their values:
+//
+//	bool                     Bool               bool
+//	uint, uint8, ..., uint64 Uint, Uint8, ...   uint, uint8, ...
+//	int, int8, ..., int64    Int, Int8, ...     int, int8, ...
+//	float32, float64         Float32, Float64   float32, float64
+//	complex64, complex128    Complex64, ...     complex64, complex128
+//	string                   String             string
+//	natural_string           NaturalString      string
+//	folded_string            FoldedString       string
+//	shortlex_string          ShortLexString     string
+//	domain_name              DomainName         string
+//	bytes                    Bytes              []byte
+//	shortlex_bytes           ShortLexBytes      []byte
+//	bit_set                  BitSet             []bool
+//	time                     Time               time.Time
+//	time_utc                 TimeUTC            time.Time
+//	time_seconds             TimeSeconds        time.Time
+//	time_millis              TimeMillis         time.Time
+//	time_micros              TimeMicros         time.Time
+//	time_zoned               TimeZoned          time.Time
+//	duration                 Duration           time.Duration
+//	date                     CivilDate          Date
+//	time_of_day              CivilTimeOfDay     TimeOfDay
+//	date_time                CivilDateTime      DateTime
+//	semver                   SemVer             Version
+//	big_int                  BigInt             *big.Int
+//	big_float                BigFloat           *big.Float
+//	big_rat                  BigRat             *big.Rat
+//
+// These functions build a Codec from the Codecs described by their arguments:
+//
+//	desc(c)                  Negate(c)          the type of c
+//	terminated(c)            Terminate(c)       the type of c
+//	nils_last(c)             NilsLast(c)        the type of c
+//	slice(c)                 SliceOf(c)         []any
+//	shortlex_slice(c)        ShortLexSliceOf(c) []any
+//	map(k, v)                MapOf(k, v)        map[any]any
+//	optional(c)              OptionalOf(c)      the type of c, or nil if absent
+//	tuple(c1, c2, ...)       c1, c2, ... in sequence, []any with an element for each Codec
+//
+// Every element of a tuple except the last must not require escaping, as defined by [Codec.RequiresTerminator],
+// so for example a string which is not last must be written as terminated(string).
+// nils_last may only be used with Codecs that [NilsLast] accepts, including slice, map, and optional.
+// The key of a map must have comparable values, so it must not be bytes, shortlex_bytes, bit_set, or semver,
+// nor be built by slice, shortlex_slice, map, or tuple.
+//
+// The returned Codec panics if a value, or an element of a value, is not of the type listed above.
+// Because the elements of slices and maps are encoded as any, Get returns []any and map[any]any for them.
+func ParseSchema(schema string) (Codec[any], error) {
+	p := schemaParser{schema, 0}
+	codec, _, err := p.parseCodec()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+	return codec, nil
+}
+
+// anyOf returns a Codec for any which encodes values of type T with codec.
+func anyOf[T any](codec Codec[T]) Codec[any] {
+	return variantCodec[any, T]{codec}
+}
+
+var schemaCodecs = map[string]Codec[any]{
+	"bool":            anyOf(Bool()),
+	"uint":            anyOf(Uint()),
+	"uint8":           anyOf(Uint8()),
+	"uint16":          anyOf(Uint16()),
+	"uint32":          anyOf(Uint32()),
+	"uint64":          anyOf(Uint64()),
+	"int":             anyOf(Int()),
+	"int8":            anyOf(Int8()),
+	"int16":           anyOf(Int16()),
+	"int32":           anyOf(Int32()),
+	"int64":           anyOf(Int64()),
+	"float32":         anyOf(Float32()),
+	"float64":         anyOf(Float64()),
+	"complex64":       anyOf(Complex64()),
+	"complex128":      anyOf(Complex128()),
+	"string":          anyOf(String()),
+	"natural_string":  anyOf(NaturalString()),
+	"folded_string":   anyOf(FoldedString()),
+	"shortlex_string": anyOf(ShortLexString()),
+	"domain_name":     anyOf(DomainName()),
+	"bytes":           anyOf(Bytes()),
+	"shortlex_bytes":  anyOf(ShortLexBytes()),
+	"bit_set":         anyOf(BitSet()),
+	"time":            anyOf(Time()),
+	"time_utc":        anyOf(TimeUTC()),
+	"time_seconds":    anyOf(TimeSeconds()),
+	"time_millis":     anyOf(TimeMillis()),
+	"time_micros":     anyOf(TimeMicros()),
+	"time_zoned":      anyOf(TimeZoned()),
+	"duration":        anyOf(Duration()),
+	"date":            anyOf(CivilDate()),
+	"time_of_day":     anyOf(CivilTimeOfDay()),
+	"date_time":       anyOf(CivilDateTime()),
+	"semver":          anyOf(SemVer()),
+	"big_int":         anyOf(BigInt()),
+	"big_float":       anyOf(BigFloat()),
+	"big_rat":         anyOf(BigRat()),
+}
+
+// schemaIncomparable contains the names in schemaCodecs whose values are not comparable, and cannot be map keys.
+var schemaIncomparable = map[string]bool{
+	"bytes":          true,
+	"shortlex_bytes": true,
+	"bit_set":        true,
+	"semver":         true,
+}
+
+// A schemaFunc builds a Codec from the Codecs described by its arguments.
+// numArgs is the number of arguments, or 0 if it takes one or more.
+// If sameType is true, the Codec's values have the type of its argument's values,
+// otherwise they are []any or map[any]any, which are not comparable.
+// If comparableKey is true, the values of its first argument must be comparable.
+type schemaFunc struct {
+	numArgs       int
+	sameType      bool
+	comparableKey bool
+	build         func(args []Codec[any]) (Codec[any], error)
+}
+
+var schemaFuncs = map[string]schemaFunc{
+	"desc":       {1, true, false, func(args []Codec[any]) (Codec[any], error) { return Negate(args[0]), nil }},
+	"terminated": {1, true, false, func(args []Codec[any]) (Codec[any], error) { return Terminate(args[0]), nil }},
+	"nils_last":  {1, true, false, schemaNilsLast},
+	"slice":      {1, false, false, func(args []Codec[any]) (Codec[any], error) { return anyOf(SliceOf(args[0])), nil }},
+	"shortlex_slice": {1, false, false, func(args []Codec[any]) (Codec[any], error) {
+		return anyOf(ShortLexSliceOf(args[0])), nil
+	}},
+	"map": {2, false, true, func(args []Codec[any]) (Codec[any], error) { return anyOf(MapOf(args[0], args[1])), nil }},
+	"optional": {1, true, false, func(args []Codec[any]) (Codec[any], error) {
+		return nilOptionalCodec{OptionalOf(args[0])}, nil
+	}},
+	"tuple": {0, false, false, newTupleCodec},
+}
+
+//nolint:nonamedreturns
+func schemaNilsLast(args []Codec[any]) (codec Codec[any], err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errNilsLastSchema
+		}
+	}()
+	return NilsLast(args[0]), nil
+}
+
+// nilsLast lets nils_last wrap the Codecs returned by anyOf, like nils_last(bytes).
+//
+//lint:ignore U1000 this is actually used
+func (c variantCodec[T, V]) nilsLast() Codec[T] {
+	return variantCodec[T, V]{NilsLast(c.codec)}
+}
+
+func newTupleCodec(args []Codec[any]) (Codec[any], error) {
+	for i, codec := range args[:len(args)-1] {
+		if codec.RequiresTerminator() {
+			return nil, tupleTerminatorError{i}
+		}
+	}
+	return tupleCodec{args}, nil
+}
+
+type schemaParser struct {
+	input string
+	pos   int
+}
+
+func (p *schemaParser) errorf(format string, args ...any) error {
+	return schemaSyntaxError{p.input, p.pos, fmt.Sprintf(format, args...)}
+}
+
+func (p *schemaParser) skipSpace() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips whitespace and then returns true and advances past b if it is next.
+func (p *schemaParser) consume(b byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == b {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func isNameByte(b byte) bool {
+	return 'a' <= b && b <= 'z' || '0' <= b && b <= '9' || b == '_'
+}
+
+func (p *schemaParser) parseName() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.input) && isNameByte(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// parseCodec returns the next Codec, and whether its values are comparable.
+func (p *schemaParser) parseCodec() (Codec[any], bool, error) {
+	p.skipSpace()
+	start := p.pos
+	name := p.parseName()
+	if name == "" {
+		if p.pos == len(p.input) {
+			return nil, false, p.errorf("unexpected end of schema")
+		}
+		return nil, false, p.errorf("expected a name, got %q", p.input[p.pos])
+	}
+	if !p.consume('(') {
+		if codec, ok := schemaCodecs[name]; ok {
+			return codec, !schemaIncomparable[name], nil
+		}
+		p.pos = start
+		if _, ok := schemaFuncs[name]; ok {
+			return nil, false, p.errorf("%s requires arguments", name)
+		}
+		return nil, false, p.errorf("unknown name %q", name)
+	}
+	fn, ok := schemaFuncs[name]
+	if !ok {
+		p.pos = start
+		return nil, false, p.errorf("unknown function %q", name)
+	}
+	var args []Codec[any]
+	var firstText string
+	var firstComparable bool
+	for {
+		p.skipSpace()
+		argStart := p.pos
+		arg, comparable, err := p.parseCodec()
+		if err != nil {
+			return nil, false, err
+		}
+		if len(args) == 0 {
+			firstText, firstComparable = strings.TrimSpace(p.input[argStart:p.pos]), comparable
+		}
+		args = append(args, arg)
+		if p.consume(')') {
+			break
+		}
+		if !p.consume(',') {
+			return nil, false, p.errorf("expected ',' or ')'")
+		}
+	}
+	if fn.numArgs != 0 && len(args) != fn.numArgs {
+		p.pos = start
+		return nil, false, p.errorf("%s requires %d arguments, got %d", name, fn.numArgs, len(args))
+	}
+	if fn.comparableKey && !firstComparable {
+		p.pos = start
+		return nil, false, p.errorf("%s: %v", name, mapKeyError{firstText})
+	}
+	codec, err := fn.build(args)
+	if err != nil {
+		p.pos = start
+		return nil, false, p.errorf("%s: %v", name, err)
+	}
+	return codec, fn.sameType && firstComparable, nil
+}
+
+// nilOptionalCodec is the Codec for values which may be nil, using an optionalCodec.
+// nil is encoded as an absent Optional, and any other value as a present Optional.
+type nilOptionalCodec struct {
+	codec Codec[Optional[any]]
+}
+
+func toOptional(value any) Optional[any] {
+	if value == nil {
+		return Optional[any]{}
+	}
+	return Some(value)
+}
+
+func (c nilOptionalCodec) Append(buf []byte, value any) []byte {
+	return c.codec.Append(buf, toOptional(value))
+}
+
+func (c nilOptionalCodec) Put(buf []byte, value any) []byte {
+	return c.codec.Put(buf, toOptional(value))
+}
+
+func (c nilOptionalCodec) Get(buf []byte) (any, []byte) {
+	value, buf := c.codec.Get(buf)
+	return value.Value, buf
+}
+
+func (c nilOptionalCodec) RequiresTerminator() bool {
+	return c.codec.RequiresTerminator()
+}
+
+func (c nilOptionalCodec) Describe() Description {
+	return Describe(c.codec)
+}
+
+func (c nilOptionalCodec) format(f *formatState, buf []byte) []byte {
+	return formatPart(f, c.codec, buf)
+}
+
+//lint:ignore U1000 this is actually used
+func (c nilOptionalCodec) nilsLast() Codec[any] {
+	return nilOptionalCodec{NilsLast(c.codec)}
+}
+
+// tupleCodec is the Codec for []any values with a fixed number of elements, each encoded by the Codec at its index.
+// The encodings of the elements are concatenated, so every Codec except the last must not require escaping,
+// and this Codec requires escaping if and only if the last Codec does.
+type tupleCodec struct {
+	codecs []Codec[any]
+}
+
+func (c tupleCodec) elements(value any) []any {
+	elems, ok := value.([]any)
+	if !ok {
+		panic(badTypeError{value})
+	}
+	if len(elems) != len(c.codecs) {
+		panic(tupleLengthError{len(c.codecs), len(elems)})
+	}
+	return elems
+}
+
+func (c tupleCodec) Append(buf []byte, value any) []byte {
+	for i, elem := range c.elements(value) {
+		buf = c.codecs[i].Append(buf, elem)
+	}
+	return buf
+}
+
+func (c tupleCodec) Put(buf []byte, value any) []byte {
+	for i, elem := range c.elements(value) {
+		buf = c.codecs[i].Put(buf, elem)
+	}
+	return buf
+}
+
+func (c tupleCodec) Get(buf []byte) (any, []byte) {
+	elems := make([]any, len(c.codecs))
+	for i, codec := range c.codecs {
+		elems[i], buf = codec.Get(buf)
+	}
+	return elems, buf
+}
+
+func (c tupleCodec) RequiresTerminator() bool {
+	return c.codecs[len(c.codecs)-1].RequiresTerminator()
+}
+
+func (c tupleCodec) Describe() Description {
+	d := Description{Kind: "tuple"}
+	for _, codec := range c.codecs {
+		d.Elems = append(d.Elems, Describe(codec))
+	}
+	return d
+}
+
+func (c tupleCodec) format(f *formatState, buf []byte) []byte {
+	f.write("(")
+	for i, codec := range c.codecs {
+		if f.out.failed {
+			break
+		}
+		if i > 0 {
+			f.write(", ")
+		}
+		buf = formatPart(f, codec, buf)
+	}
+	f.write(")")
+	return buf
+}
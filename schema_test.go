@@ -0,0 +1,182 @@
+package lexy_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+func mustParseSchema(t *testing.T, schema string) lexy.Codec[any] {
+	t.Helper()
+	codec, err := lexy.ParseSchema(schema)
+	require.NoError(t, err)
+	return codec
+}
+
+// testSchemaRoundTrip tests that values round trip, and are encoded in order.
+func testSchemaRoundTrip(t *testing.T, codec lexy.Codec[any], values ...any) {
+	t.Helper()
+	var prev []byte
+	for i, value := range values {
+		buf := codec.Append(nil, value)
+		got, rest := codec.Get(buf)
+		assert.Equal(t, value, got)
+		assert.Empty(t, rest)
+		putBuf := make([]byte, len(buf))
+		assert.Empty(t, codec.Put(putBuf, value))
+		assert.True(t, bytes.Equal(buf, putBuf), "Put wrote %X, Append wrote %X", putBuf, buf)
+		if i > 0 {
+			assert.Less(t, prev, buf, "values[%d] and values[%d] are not in order", i-1, i)
+		}
+		prev = buf
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	t.Parallel()
+	codec := mustParseSchema(t, "tuple(uint32, desc(time), terminated(string), slice(int64))")
+	assert.True(t, codec.RequiresTerminator())
+	when := time.Date(2024, 2, 29, 12, 30, 45, 0, time.UTC)
+	value := []any{uint32(7), when, "a\x00b", []any{int64(-1), int64(2)}}
+	expected := concat(
+		lexy.Uint32().Append(nil, 7),
+		lexy.Negate(lexy.Time()).Append(nil, when),
+		lexy.TerminatedString().Append(nil, "a\x00b"),
+		lexy.SliceOf(lexy.Int64()).Append(nil, []int64{-1, 2}),
+	)
+	assert.Equal(t, expected, codec.Append(nil, value))
+	got, rest := codec.Get(expected)
+	assert.Empty(t, rest)
+	require.IsType(t, []any{}, got)
+	gotValue, _ := got.([]any)
+	assert.Equal(t, value[0], gotValue[0])
+	assert.True(t, when.Equal(gotValue[1].(time.Time)))
+	assert.Equal(t, value[2:], gotValue[2:])
+}
+
+func TestParseSchemaNames(t *testing.T) {
+	t.Parallel()
+	testSchemaRoundTrip(t, mustParseSchema(t, "bool"), false, true)
+	testSchemaRoundTrip(t, mustParseSchema(t, "int8"), int8(-1), int8(0), int8(1))
+	testSchemaRoundTrip(t, mustParseSchema(t, "uint"), uint(0), uint(1))
+	testSchemaRoundTrip(t, mustParseSchema(t, "float64"), -1.5, 0.0, 1.5)
+	testSchemaRoundTrip(t, mustParseSchema(t, "string"), "", "a", "b")
+	testSchemaRoundTrip(t, mustParseSchema(t, "natural_string"), "a2", "a10")
+	testSchemaRoundTrip(t, mustParseSchema(t, "bytes"), []byte{}, []byte{0})
+	testSchemaRoundTrip(t, mustParseSchema(t, "duration"), -time.Second, time.Duration(0), time.Hour)
+	testSchemaRoundTrip(t, mustParseSchema(t, "date"), lexy.Date{2024, time.February, 29}, lexy.Date{2025, 1, 1})
+	testSchemaRoundTrip(t, mustParseSchema(t, " semver "), lexy.Version{Major: 1}, lexy.Version{Major: 2})
+}
+
+func TestParseSchemaFuncs(t *testing.T) {
+	t.Parallel()
+	testSchemaRoundTrip(t, mustParseSchema(t, "desc(int32)"), int32(1), int32(0), int32(-1))
+	testSchemaRoundTrip(t, mustParseSchema(t, "slice(string)"), []any(nil), []any{}, []any{""}, []any{"a"})
+	testSchemaRoundTrip(t, mustParseSchema(t, "nils_last(slice(string))"), []any{}, []any{"a"}, []any(nil))
+	testSchemaRoundTrip(t, mustParseSchema(t, "shortlex_slice(uint8)"), []any{}, []any{uint8(9)},
+		[]any{uint8(0), uint8(0)})
+	testSchemaRoundTrip(t, mustParseSchema(t, "map(string, int32)"), map[any]any(nil), map[any]any{},
+		map[any]any{"a": int32(1)})
+	testSchemaRoundTrip(t, mustParseSchema(t, "map(desc(optional(string)), int32)"), map[any]any{},
+		map[any]any{"a": int32(2)}, map[any]any{nil: int32(1)})
+	testSchemaRoundTrip(t, mustParseSchema(t, "optional(string)"), nil, "", "a")
+	testSchemaRoundTrip(t, mustParseSchema(t, "nils_last(optional(string))"), "", "a", nil)
+	testSchemaRoundTrip(t, mustParseSchema(t, "tuple(int8, desc(uint8))"),
+		[]any{int8(0), uint8(1)}, []any{int8(0), uint8(0)}, []any{int8(1), uint8(0)})
+	testSchemaRoundTrip(t, mustParseSchema(t, "tuple( terminated( string ) ,\n\tstring )"),
+		[]any{"", "z"}, []any{"a", ""}, []any{"a", "b"})
+	assert.True(t, mustParseSchema(t, "tuple(string)").RequiresTerminator())
+	assert.False(t, mustParseSchema(t, "tuple(terminated(string), int8)").RequiresTerminator())
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		schema string
+		msg    string
+	}{
+		{"", `invalid schema "" at offset 0: unexpected end of schema`},
+		{"strings", `invalid schema "strings" at offset 0: unknown name "strings"`},
+		{"uint32(int8)", `invalid schema "uint32(int8)" at offset 0: unknown function "uint32"`},
+		{"slice", `invalid schema "slice" at offset 0: slice requires arguments`},
+		{"slice()", `invalid schema "slice()" at offset 6: expected a name, got ')'`},
+		{"slice(int8", `invalid schema "slice(int8" at offset 10: expected ',' or ')'`},
+		{"slice(int8) x", `invalid schema "slice(int8) x" at offset 12: unexpected "x"`},
+		{"map(int8)", `invalid schema "map(int8)" at offset 0: map requires 2 arguments, got 1`},
+		{"desc(int8, int8)", `invalid schema "desc(int8, int8)" at offset 0: desc requires 1 arguments, got 2`},
+		{"Int8", `invalid schema "Int8" at offset 0: expected a name, got 'I'`},
+		{
+			"tuple(int8, string, int8)",
+			`invalid schema "tuple(int8, string, int8)" at offset 0: ` +
+				"tuple: element 1 requires a terminator but is not last, use terminated(...)",
+		},
+		{
+			"slice(nils_last(int8))",
+			`invalid schema "slice(nils_last(int8))" at offset 6: nils_last: argument does not encode nils`,
+		},
+		{
+			"nils_last(desc(bytes))",
+			`invalid schema "nils_last(desc(bytes))" at offset 0: nils_last: argument does not encode nils`,
+		},
+		{"map(bytes, int8)", `invalid schema "map(bytes, int8)" at offset 0: map: key bytes is not comparable`},
+		{
+			"map(shortlex_bytes, int8)",
+			`invalid schema "map(shortlex_bytes, int8)" at offset 0: map: key shortlex_bytes is not comparable`,
+		},
+		{"map(bit_set, int8)", `invalid schema "map(bit_set, int8)" at offset 0: map: key bit_set is not comparable`},
+		{"map(semver, int8)", `invalid schema "map(semver, int8)" at offset 0: map: key semver is not comparable`},
+		{
+			"map(slice(int8), int8)",
+			`invalid schema "map(slice(int8), int8)" at offset 0: map: key slice(int8) is not comparable`,
+		},
+		{
+			"map(shortlex_slice(int8), int8)",
+			`invalid schema "map(shortlex_slice(int8), int8)" at offset 0: ` +
+				"map: key shortlex_slice(int8) is not comparable",
+		},
+		{
+			"map(map(int8, int8), int8)",
+			`invalid schema "map(map(int8, int8), int8)" at offset 0: map: key map(int8, int8) is not comparable`,
+		},
+		{
+			"map(tuple(int8, bytes), int8)",
+			`invalid schema "map(tuple(int8, bytes), int8)" at offset 0: map: key tuple(int8, bytes) is not comparable`,
+		},
+		{
+			"map(desc(optional(bytes)), int8)",
+			`invalid schema "map(desc(optional(bytes)), int8)" at offset 0: ` +
+				"map: key desc(optional(bytes)) is not comparable",
+		},
+		{
+			"slice(map( bytes , int8))",
+			`invalid schema "slice(map( bytes , int8))" at offset 6: map: key bytes is not comparable`,
+		},
+	} {
+		t.Run(tt.schema, func(t *testing.T) {
+			t.Parallel()
+			_, err := lexy.ParseSchema(tt.schema)
+			require.EqualError(t, err, tt.msg)
+		})
+	}
+}
+
+func TestParseSchemaPanics(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() {
+		mustParseSchema(t, "uint32").Append(nil, 1)
+	})
+	assert.Panics(t, func() {
+		mustParseSchema(t, "slice(uint32)").Append(nil, []any{uint32(1), "a"})
+	})
+	assert.PanicsWithError(t, "bad type string", func() {
+		mustParseSchema(t, "tuple(uint32)").Append(nil, "a")
+	})
+	assert.PanicsWithError(t, "tuple of length 1 must have length 2", func() {
+		mustParseSchema(t, "tuple(uint32, uint32)").Append(nil, []any{uint32(1)})
+	})
+}
@@ -0,0 +1,243 @@
+package lexy
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version, as defined by Semantic Versioning 2.0.0 (https://semver.org).
+//
+// Prerelease and Build are the dot-separated pre-release and build metadata identifiers,
+// without the leading '-' or '+'.
+// A Version with no pre-release identifiers is a release, whether Prerelease is nil or empty.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+}
+
+// ParseVersion parses s as a semantic version, such as "1.2.3-rc.1+build.5".
+// A leading 'v' is not allowed, and numeric fields must not have leading zeros.
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	rest, build, hasBuild := strings.Cut(s, "+")
+	rest, pre, hasPre := strings.Cut(rest, "-")
+	nums := strings.Split(rest, ".")
+	if len(nums) != 3 { //nolint:mnd
+		return Version{}, versionSyntaxError{s, "expected major.minor.patch"}
+	}
+	for i, dst := range []*uint64{&v.Major, &v.Minor, &v.Patch} {
+		if !isNumericIdentifier(nums[i]) {
+			return Version{}, versionSyntaxError{s, "invalid version number " + strconv.Quote(nums[i])}
+		}
+		n, err := strconv.ParseUint(nums[i], 10, 64)
+		if err != nil {
+			return Version{}, versionSyntaxError{s, err.Error()}
+		}
+		*dst = n
+	}
+	if hasPre {
+		v.Prerelease = strings.Split(pre, ".")
+		for _, id := range v.Prerelease {
+			if !isIdentifier(id) || isAllDigits(id) && !isNumericIdentifier(id) {
+				return Version{}, versionSyntaxError{s, "invalid pre-release identifier " + strconv.Quote(id)}
+			}
+		}
+	}
+	if hasBuild {
+		v.Build = strings.Split(build, ".")
+		for _, id := range v.Build {
+			if !isIdentifier(id) {
+				return Version{}, versionSyntaxError{s, "invalid build identifier " + strconv.Quote(id)}
+			}
+		}
+	}
+	return v, nil
+}
+
+// isIdentifier returns true if s is a non-empty string of ASCII alphanumerics and hyphens.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := range len(s) {
+		b := s[i]
+		if !isDigit(b) && !('a' <= b && b <= 'z') && !('A' <= b && b <= 'Z') && b != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := range len(s) {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNumericIdentifier returns true if s is "0", or all digits without a leading zero.
+func isNumericIdentifier(s string) bool {
+	return s == "0" || isAllDigits(s) && s[0] != '0'
+}
+
+// String returns v in the form "1.2.3-rc.1+build.5".
+func (v Version) String() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatUint(v.Major, 10))
+	sb.WriteByte('.')
+	sb.WriteString(strconv.FormatUint(v.Minor, 10))
+	sb.WriteByte('.')
+	sb.WriteString(strconv.FormatUint(v.Patch, 10))
+	if len(v.Prerelease) > 0 {
+		sb.WriteByte('-')
+		sb.WriteString(strings.Join(v.Prerelease, "."))
+	}
+	if len(v.Build) > 0 {
+		sb.WriteByte('+')
+		sb.WriteString(strings.Join(v.Build, "."))
+	}
+	return sb.String()
+}
+
+// semVerCodec is the Codec for Versions, in Semantic Versioning 2.0.0 precedence order.
+//
+// A Version is encoded as:
+//
+//	Major, Minor, and Patch, using the order-preserving varint encoding in varint.go
+//	for each pre-release identifier:
+//	  numericIDMarker, the number of digits using the varint encoding, then the digits as-is
+//	  or, alphaIDMarker, then the identifier escaped and terminated
+//	endPrereleaseMarker if there were pre-release identifiers, otherwise releaseMarker
+//	for each build identifier:
+//	  buildIDMarker, then the identifier escaped and terminated
+//	endBuildMarker
+//
+// Numeric identifiers are ordered by their number of digits and then by their digits,
+// which is numeric order because they have no leading zeros.
+// A numeric identifier is ordered before an alphanumeric one,
+// and fewer pre-release identifiers are ordered before more if all the preceding ones are equal.
+// A release is ordered after any pre-release with the same major, minor, and patch numbers.
+//
+// Build metadata does not affect precedence, but it must be encoded to round trip.
+// It is encoded last, so it only orders Versions with equal precedence, as a tiebreaker.
+//
+// Pre-release identifiers which are all digits are always encoded as numeric identifiers,
+// even if they have leading zeros, so they still round trip.
+// Every encoding is self-delimiting, so this Codec does not require escaping.
+type semVerCodec struct{}
+
+const (
+	endPrereleaseMarker byte = 0x00
+	numericIDMarker     byte = 0x01
+	alphaIDMarker       byte = 0x02
+	releaseMarker       byte = 0x03
+	endBuildMarker      byte = 0x00
+	buildIDMarker       byte = 0x01
+)
+
+func (semVerCodec) Append(buf []byte, value Version) []byte {
+	buf = appendUvarint(buf, value.Major)
+	buf = appendUvarint(buf, value.Minor)
+	buf = appendUvarint(buf, value.Patch)
+	for _, id := range value.Prerelease {
+		if isAllDigits(id) {
+			buf = append(buf, numericIDMarker)
+			buf = appendUvarint(buf, uint64(len(id)))
+			buf = append(buf, id...)
+		} else {
+			buf = append(buf, alphaIDMarker)
+			buf = stdTermString.Append(buf, id)
+		}
+	}
+	if len(value.Prerelease) > 0 {
+		buf = append(buf, endPrereleaseMarker)
+	} else {
+		buf = append(buf, releaseMarker)
+	}
+	for _, id := range value.Build {
+		buf = append(buf, buildIDMarker)
+		buf = stdTermString.Append(buf, id)
+	}
+	return append(buf, endBuildMarker)
+}
+
+func (semVerCodec) Put(buf []byte, value Version) []byte {
+	buf = putUvarint(buf, value.Major)
+	buf = putUvarint(buf, value.Minor)
+	buf = putUvarint(buf, value.Patch)
+	for _, id := range value.Prerelease {
+		if isAllDigits(id) {
+			buf[0] = numericIDMarker
+			buf = putUvarint(buf[1:], uint64(len(id)))
+			buf = copyAll(buf, []byte(id))
+		} else {
+			buf[0] = alphaIDMarker
+			buf = stdTermString.Put(buf[1:], id)
+		}
+	}
+	if len(value.Prerelease) > 0 {
+		buf[0] = endPrereleaseMarker
+	} else {
+		buf[0] = releaseMarker
+	}
+	buf = buf[1:]
+	for _, id := range value.Build {
+		buf[0] = buildIDMarker
+		buf = stdTermString.Put(buf[1:], id)
+	}
+	buf[0] = endBuildMarker
+	return buf[1:]
+}
+
+func (semVerCodec) Get(buf []byte) (Version, []byte) {
+	var v Version
+	v.Major, buf = getUvarint(buf)
+	v.Minor, buf = getUvarint(buf)
+	v.Patch, buf = getUvarint(buf)
+	var id string
+	for done := false; !done; {
+		switch marker := buf[0]; marker {
+		case numericIDMarker:
+			var n uint64
+			n, buf = getUvarint(buf[1:])
+			_ = buf[:n] // check that we have enough
+			v.Prerelease = append(v.Prerelease, string(buf[:n]))
+			buf = buf[n:]
+		case alphaIDMarker:
+			id, buf = stdTermString.Get(buf[1:])
+			v.Prerelease = append(v.Prerelease, id)
+		case endPrereleaseMarker, releaseMarker:
+			buf = buf[1:]
+			done = true
+		default:
+			panic(unknownPrefixError{marker})
+		}
+	}
+	for {
+		switch marker := buf[0]; marker {
+		case buildIDMarker:
+			id, buf = stdTermString.Get(buf[1:])
+			v.Build = append(v.Build, id)
+		case endBuildMarker:
+			return v, buf[1:]
+		default:
+			panic(unknownPrefixError{marker})
+		}
+	}
+}
+
+func (semVerCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (semVerCodec) Describe() Description {
+	return Description{Kind: "semver"}
+}
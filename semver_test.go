@@ -0,0 +1,189 @@
+package lexy_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+func mustParseVersion(t *testing.T, s string) lexy.Version {
+	t.Helper()
+	v, err := lexy.ParseVersion(s)
+	require.NoError(t, err)
+	return v
+}
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		s    string
+		want lexy.Version
+	}{
+		{"0.0.0", lexy.Version{}},
+		{"1.2.3", lexy.Version{1, 2, 3, nil, nil}},
+		{"10.20.30", lexy.Version{10, 20, 30, nil, nil}},
+		{"1.0.0-alpha", lexy.Version{1, 0, 0, []string{"alpha"}, nil}},
+		{"1.0.0-alpha.1", lexy.Version{1, 0, 0, []string{"alpha", "1"}, nil}},
+		{"1.0.0-0.3.7", lexy.Version{1, 0, 0, []string{"0", "3", "7"}, nil}},
+		{"1.0.0-x-y-z.--", lexy.Version{1, 0, 0, []string{"x-y-z", "--"}, nil}},
+		{"1.0.0+20130313144700", lexy.Version{1, 0, 0, nil, []string{"20130313144700"}}},
+		{"1.0.0-beta+exp.sha.5114f85", lexy.Version{1, 0, 0, []string{"beta"}, []string{"exp", "sha", "5114f85"}}},
+		{"1.0.0+21AF26D3----117B344092BD", lexy.Version{1, 0, 0, nil, []string{"21AF26D3----117B344092BD"}}},
+		{"1.0.0+001", lexy.Version{1, 0, 0, nil, []string{"001"}}},
+		{"1.0.0-0a", lexy.Version{1, 0, 0, []string{"0a"}, nil}},
+		{
+			"18446744073709551615.0.0",
+			lexy.Version{18446744073709551615, 0, 0, nil, nil},
+		},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			got, err := lexy.ParseVersion(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.s, got.String())
+		})
+	}
+}
+
+func TestParseVersionInvalid(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{
+		"",
+		"1",
+		"1.2",
+		"1.2.3.4",
+		"v1.2.3",
+		"01.2.3",
+		"1.02.3",
+		"1.2.03",
+		"1.2.-3",
+		"1.2.3-",
+		"1.2.3+",
+		"1.2.3-01",
+		"1.2.3-alpha..1",
+		"1.2.3-alpha_1",
+		"1.2.3+build..1",
+		"1.2.3+build!",
+		"18446744073709551616.0.0",
+	} {
+		t.Run(s, func(t *testing.T) {
+			t.Parallel()
+			_, err := lexy.ParseVersion(s)
+			assert.ErrorContains(t, err, "invalid semantic version")
+		})
+	}
+}
+
+func TestSemVer(t *testing.T) {
+	t.Parallel()
+	codec := lexy.SemVer()
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.Version]{
+		{"0.0.0", lexy.Version{}, []byte{0x00, 0x00, 0x00, 0x03, 0x00}},
+		{"1.2.300", lexy.Version{1, 2, 300, nil, nil}, []byte{
+			0x01, 0x01,
+			0x01, 0x02,
+			0x02, 0x01, 0x2C,
+			0x03, 0x00,
+		}},
+		{"1.0.0-rc.10", lexy.Version{1, 0, 0, []string{"rc", "10"}, nil}, []byte{
+			0x01, 0x01, 0x00, 0x00,
+			0x02, 'r', 'c', term,
+			0x01, 0x01, 0x02, '1', '0',
+			0x00,
+			0x00,
+		}},
+		{"1.0.0+b.1", lexy.Version{1, 0, 0, nil, []string{"b", "1"}}, []byte{
+			0x01, 0x01, 0x00, 0x00,
+			0x03,
+			0x01, 'b', term,
+			0x01, '1', term,
+			0x00,
+		}},
+		{"leading zeros", lexy.Version{0, 0, 0, []string{"007"}, nil}, []byte{
+			0x00, 0x00, 0x00,
+			0x01, 0x01, 0x03, '0', '0', '7',
+			0x00,
+			0x00,
+		}},
+		{"escaped", lexy.Version{0, 0, 0, []string{"\x00"}, []string{"\x01"}}, []byte{
+			0x00, 0x00, 0x00,
+			0x02, esc, 0x00, term,
+			0x00,
+			0x01, esc, 0x01, term,
+			0x00,
+		}},
+	})
+}
+
+func TestSemVerEmptySlices(t *testing.T) {
+	t.Parallel()
+	codec := lexy.SemVer()
+	got, _ := codec.Get(codec.Append(nil, lexy.Version{1, 2, 3, []string{}, []string{}}))
+	assert.Equal(t, lexy.Version{1, 2, 3, nil, nil}, got)
+}
+
+func TestSemVerOrdering(t *testing.T) {
+	t.Parallel()
+	var tests []testCase[lexy.Version]
+	for _, s := range []string{
+		"0.0.0-0",
+		"0.0.0",
+		"0.0.1",
+		"0.1.0",
+		"0.9.0",
+		"0.10.0",
+		"1.0.0-0",
+		"1.0.0-1",
+		"1.0.0-2",
+		"1.0.0-10",
+		"1.0.0-100",
+		"1.0.0-1a",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.0+build",
+		"1.0.0+build.1",
+		"1.0.1-alpha",
+		"1.9.0",
+		"1.10.0",
+		"1.11.0",
+		"2.0.0",
+		"10.0.0",
+		"18446744073709551615.0.0",
+	} {
+		tests = append(tests, testCase[lexy.Version]{s, mustParseVersion(t, s), nil})
+	}
+	testOrdering(t, lexy.SemVer(), tests)
+}
+
+func TestSemVerUnknownMarker(t *testing.T) {
+	t.Parallel()
+	codec := lexy.SemVer()
+	assert.PanicsWithError(t, "unexpected prefix 0x7F", func() {
+		codec.Get([]byte{0x00, 0x00, 0x00, 0x7F, 0x00})
+	})
+	assert.PanicsWithError(t, "unexpected prefix 0x7F", func() {
+		codec.Get([]byte{0x00, 0x00, 0x00, 0x03, 0x7F})
+	})
+}
+
+//nolint:paralleltest // allocation counts are unreliable when run in parallel
+func TestSemVerPutNoAlloc(t *testing.T) {
+	codec := lexy.SemVer()
+	value := mustParseVersion(t, "1.2.3-rc.10.a-pre-release-identifier-long-enough-to-escape-a-stack-buffer+build.5")
+	buf := make([]byte, 128)
+	allocs := testing.AllocsPerRun(100, func() {
+		codec.Put(buf, value)
+	})
+	assert.Zero(t, allocs)
+}
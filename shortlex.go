@@ -0,0 +1,162 @@
+package lexy
+
+// Codecs for strings, []byte, and slices in shortlex order,
+// where shorter values are ordered first, and values of the same length are ordered lexicographically.
+// This is also known as length-lexicographic or radix order.
+//
+// A value is encoded as its length followed by its contents.
+// The length is encoded with the order-preserving variable-length encoding in varint.go,
+// so the length is the primary sort key and the contents are the secondary sort key.
+// The length is the number of bytes for strings and []byte, and the number of elements for slices.
+//
+// Because the length is known before the contents are read,
+// no encoding can be a prefix of another, and these Codecs never require escaping.
+// The encoded elements of a slice are still escaped and terminated if elemCodec requires it,
+// because their individual lengths are not known.
+type (
+	shortLexStringCodec struct{}
+
+	shortLexBytesCodec struct {
+		prefix Prefix
+	}
+
+	shortLexSliceCodec[E any] struct {
+		elemCodec Codec[E]
+		prefix    Prefix
+	}
+)
+
+func (shortLexStringCodec) Append(buf []byte, value string) []byte {
+	buf = appendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func (shortLexStringCodec) Put(buf []byte, value string) []byte {
+	buf = putUvarint(buf, uint64(len(value)))
+	return copyAll(buf, []byte(value))
+}
+
+func (shortLexStringCodec) Get(buf []byte) (string, []byte) {
+	n, buf := getUvarint(buf)
+	_ = buf[:n] // check that we have enough
+	return string(buf[:n]), buf[n:]
+}
+
+func (shortLexStringCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (shortLexStringCodec) Describe() Description {
+	return Description{Kind: "shortlex_string"}
+}
+
+func (c shortLexBytesCodec) Append(buf, value []byte) []byte {
+	done, buf := c.prefix.Append(buf, value == nil)
+	if done {
+		return buf
+	}
+	buf = appendUvarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func (c shortLexBytesCodec) Put(buf, value []byte) []byte {
+	done, buf := c.prefix.Put(buf, value == nil)
+	if done {
+		return buf
+	}
+	buf = putUvarint(buf, uint64(len(value)))
+	return copyAll(buf, value)
+}
+
+func (c shortLexBytesCodec) Get(buf []byte) ([]byte, []byte) {
+	done, buf := c.prefix.Get(buf)
+	if done {
+		return nil, buf
+	}
+	n, buf := getUvarint(buf)
+	_ = buf[:n] // check that we have enough
+	return append([]byte{}, buf[:n]...), buf[n:]
+}
+
+func (shortLexBytesCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (c shortLexBytesCodec) Describe() Description {
+	return Description{Kind: "shortlex_bytes", NilsLast: isNilsLast(c.prefix)}
+}
+
+//lint:ignore U1000 this is actually used
+func (shortLexBytesCodec) nilsLast() Codec[[]byte] {
+	return shortLexBytesCodec{PrefixNilsLast}
+}
+
+func (c shortLexSliceCodec[E]) Append(buf []byte, value []E) []byte {
+	done, buf := c.prefix.Append(buf, value == nil)
+	if done {
+		return buf
+	}
+	buf = appendUvarint(buf, uint64(len(value)))
+	for _, elem := range value {
+		buf = c.elemCodec.Append(buf, elem)
+	}
+	return buf
+}
+
+func (c shortLexSliceCodec[E]) Put(buf []byte, value []E) []byte {
+	done, buf := c.prefix.Put(buf, value == nil)
+	if done {
+		return buf
+	}
+	buf = putUvarint(buf, uint64(len(value)))
+	for _, elem := range value {
+		buf = c.elemCodec.Put(buf, elem)
+	}
+	return buf
+}
+
+func (c shortLexSliceCodec[E]) Get(buf []byte) ([]E, []byte) {
+	done, buf := c.prefix.Get(buf)
+	if done {
+		return nil, buf
+	}
+	n, buf := getUvarint(buf)
+	// Don't trust n for the capacity, every element is at least one byte if n is valid.
+	values := make([]E, 0, min(n, uint64(len(buf))))
+	var value E
+	for range n {
+		value, buf = c.elemCodec.Get(buf)
+		values = append(values, value)
+	}
+	return values, buf
+}
+
+func (shortLexSliceCodec[E]) RequiresTerminator() bool {
+	return false
+}
+
+func (c shortLexSliceCodec[E]) Describe() Description {
+	return Description{Kind: "shortlex_slice", Elem: describeRef(c.elemCodec), NilsLast: isNilsLast(c.prefix)}
+}
+
+func (c shortLexSliceCodec[E]) format(f *formatState, buf []byte) []byte {
+	done, buf := formatNillable(f, c.prefix, buf)
+	if done {
+		return buf
+	}
+	n, buf := getUvarint(buf)
+	f.write("[")
+	for i := uint64(0); i < n && !f.out.failed; i++ {
+		if i > 0 {
+			f.write(", ")
+		}
+		buf = formatPart(f, c.elemCodec, buf)
+	}
+	f.write("]")
+	return buf
+}
+
+//lint:ignore U1000 this is actually used
+func (c shortLexSliceCodec[E]) nilsLast() Codec[[]E] {
+	return shortLexSliceCodec[E]{c.elemCodec, PrefixNilsLast}
+}
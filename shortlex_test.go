@@ -0,0 +1,133 @@
+package lexy_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/phiryll/lexy"
+)
+
+func TestShortLexString(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ShortLexString()
+	assert.False(t, codec.RequiresTerminator())
+	long := strings.Repeat("x", 300)
+	testCodec(t, codec, []testCase[string]{
+		{"empty", "", []byte{0x00}},
+		{"a", "a", []byte{0x01, 0x01, 'a'}},
+		{"xyz", "xyz", []byte{0x01, 0x03, 'x', 'y', 'z'}},
+		{"zeros", "\x00\x00", []byte{0x01, 0x02, 0x00, 0x00}},
+		{"300 bytes", long, concat([]byte{0x02, 0x01, 0x2C}, []byte(long))},
+	})
+}
+
+func TestShortLexStringOrdering(t *testing.T) {
+	t.Parallel()
+	testOrdering(t, lexy.ShortLexString(), []testCase[string]{
+		{"empty", "", nil},
+		{"\\x00", "\x00", nil},
+		{"a", "a", nil},
+		{"b", "b", nil},
+		{"\\xFF", "\xFF", nil},
+		{"aa", "aa", nil},
+		{"ab", "ab", nil},
+		{"b\\x00", "b\x00", nil},
+		{"aaaa", "aaaa", nil},
+		{"255 bytes", strings.Repeat("\xFF", 255), nil},
+		{"256 bytes", strings.Repeat("\x00", 256), nil},
+		{"70000 bytes", strings.Repeat("\x00", 70000), nil},
+	})
+}
+
+func TestShortLexBytes(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ShortLexBytes()
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[[]byte]{
+		{"nil", nil, []byte{pNilFirst}},
+		{"empty", []byte{}, []byte{pNonNil, 0x00}},
+		{"[0, 1]", []byte{0, 1}, []byte{pNonNil, 0x01, 0x02, 0x00, 0x01}},
+	})
+	testCodec(t, lexy.NilsLast(codec), []testCase[[]byte]{
+		{"nil", nil, []byte{pNilLast}},
+		{"empty", []byte{}, []byte{pNonNil, 0x00}},
+		{"[0, 1]", []byte{0, 1}, []byte{pNonNil, 0x01, 0x02, 0x00, 0x01}},
+	})
+	testOrdering(t, codec, []testCase[[]byte]{
+		{"nil", nil, nil},
+		{"empty", []byte{}, nil},
+		{"[255]", []byte{255}, nil},
+		{"[0, 0]", []byte{0, 0}, nil},
+	})
+	testOrdering(t, lexy.NilsLast(codec), []testCase[[]byte]{
+		{"empty", []byte{}, nil},
+		{"[255]", []byte{255}, nil},
+		{"[0, 0]", []byte{0, 0}, nil},
+		{"nil", nil, nil},
+	})
+}
+
+func TestShortLexSlice(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ShortLexSliceOf(lexy.Int16())
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[[]int16]{
+		{"nil", nil, []byte{pNilFirst}},
+		{"empty", []int16{}, []byte{pNonNil, 0x00}},
+		{"[-1]", []int16{-1}, []byte{pNonNil, 0x01, 0x01, 0x7F, 0xFF}},
+		{"[0, 1]", []int16{0, 1}, []byte{pNonNil, 0x01, 0x02, 0x80, 0x00, 0x80, 0x01}},
+	})
+	testOrdering(t, codec, []testCase[[]int16]{
+		{"nil", nil, nil},
+		{"empty", []int16{}, nil},
+		{"[-1]", []int16{-1}, nil},
+		{"[100]", []int16{100}, nil},
+		{"[-5, 3]", []int16{-5, 3}, nil},
+		{"[-5, 4]", []int16{-5, 4}, nil},
+		{"[1, 1]", []int16{1, 1}, nil},
+		{"[0, 0, 0]", []int16{0, 0, 0}, nil},
+	})
+}
+
+func TestShortLexSliceString(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ShortLexSliceOf(lexy.String())
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[[]string]{
+		{"nil", nil, []byte{pNilFirst}},
+		{"empty", []string{}, []byte{pNonNil, 0x00}},
+		{"[\"\"]", []string{""}, []byte{pNonNil, 0x01, 0x01, term}},
+		{"[a, \"\", b]", []string{"a", "", "b"}, []byte{
+			pNonNil, 0x01, 0x03,
+			'a', term,
+			term,
+			'b', term,
+		}},
+	})
+	testOrdering(t, codec, []testCase[[]string]{
+		{"[z]", []string{"z"}, nil},
+		{"[a, a]", []string{"a", "a"}, nil},
+		{"[a, b]", []string{"a", "b"}, nil},
+		{"[ab, \"\"]", []string{"ab", ""}, nil},
+	})
+	// Embedded without Terminate.
+	nested := lexy.SliceOf(lexy.ShortLexSliceOf(lexy.ShortLexString()))
+	testCodec(t, nested, fillTestData(nested, []testCase[[][]string]{
+		{"nested", [][]string{{"a", "bc"}, {}, nil, {""}}, nil},
+	}))
+}
+
+func TestShortLexGetBadLength(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() {
+		lexy.ShortLexString().Get([]byte{0x09, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	})
+	assert.Panics(t, func() {
+		lexy.ShortLexString().Get([]byte{0x01, 0x05, 'a', 'b'})
+	})
+	assert.Panics(t, func() {
+		lexy.ShortLexSliceOf(lexy.Uint8()).Get([]byte{pNonNil, 0x08, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 1})
+	})
+}
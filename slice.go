@@ -54,6 +54,26 @@ func (sliceCodec[E]) RequiresTerminator() bool {
 	return true
 }
 
+func (c sliceCodec[E]) Describe() Description {
+	return Description{Kind: "slice", Elem: describeRef(c.elemCodec), NilsLast: isNilsLast(c.prefix)}
+}
+
+func (c sliceCodec[E]) format(f *formatState, buf []byte) []byte {
+	done, buf := formatNillable(f, c.prefix, buf)
+	if done {
+		return buf
+	}
+	f.write("[")
+	for i := 0; f.more(buf); i++ {
+		if i > 0 {
+			f.write(", ")
+		}
+		buf = formatPart(f, c.elemCodec, buf)
+	}
+	f.write("]")
+	return buf
+}
+
 //lint:ignore U1000 this is actually used
 func (c sliceCodec[E]) nilsLast() Codec[[]E] {
 	return sliceCodec[E]{c.elemCodec, PrefixNilsLast}
@@ -0,0 +1,74 @@
+package lexy
+
+import (
+	"slices"
+	"strings"
+)
+
+// splitCodec is the Codec for strings composed of parts separated by sep,
+// like hostnames and paths, ordered part by part.
+// If reverse is true, the parts are encoded last to first, as is done for hostnames.
+//
+// The empty string is encoded as zero bytes.
+// Any other string is split on sep, and each part is escaped and terminated, in order.
+// Because the terminator is less than any other byte, a part is ordered before any longer part it is a prefix of,
+// so "/a/b" < "/a-b" for paths, and "www.example.com" < "www.example-a.com" for hostnames.
+// The encoding of a string is a prefix of the encoding of any string with additional trailing parts,
+// so a standard prefix scan for the encoding of "example.com" or "/a" will find every string
+// with those initial parts, including "example.com" and "/a" themselves.
+//
+// There is no way to encode a single empty part, but that is only produced by splitting the empty string.
+// Get will fully consume its argument buffer.
+type splitCodec struct {
+	sep     string
+	reverse bool
+}
+
+func (c splitCodec) parts(value string) []string {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, c.sep)
+	if c.reverse {
+		slices.Reverse(parts)
+	}
+	return parts
+}
+
+func (c splitCodec) Append(buf []byte, value string) []byte {
+	for _, part := range c.parts(value) {
+		buf = stdTermString.Append(buf, part)
+	}
+	return buf
+}
+
+func (c splitCodec) Put(buf []byte, value string) []byte {
+	for _, part := range c.parts(value) {
+		buf = stdTermString.Put(buf, part)
+	}
+	return buf
+}
+
+func (c splitCodec) Get(buf []byte) (string, []byte) {
+	var parts []string
+	var part string
+	for len(buf) > 0 {
+		part, buf = stdTermString.Get(buf)
+		parts = append(parts, part)
+	}
+	if c.reverse {
+		slices.Reverse(parts)
+	}
+	return strings.Join(parts, c.sep), buf
+}
+
+func (splitCodec) RequiresTerminator() bool {
+	return true
+}
+
+func (c splitCodec) Describe() Description {
+	if c.reverse {
+		return Description{Kind: "domain_name"}
+	}
+	return Description{Kind: "path_segments", Params: []any{c.sep}}
+}
@@ -0,0 +1,128 @@
+package lexy_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/phiryll/lexy"
+)
+
+func TestDomainName(t *testing.T) {
+	t.Parallel()
+	codec := lexy.DomainName()
+	assert.True(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[string]{
+		{"empty", "", []byte{}},
+		{"com", "com", []byte{'c', 'o', 'm', term}},
+		{"example.com", "example.com", concat(
+			[]byte("com"), []byte{term},
+			[]byte("example"), []byte{term},
+		)},
+		{"www.example.com", "www.example.com", concat(
+			[]byte("com"), []byte{term},
+			[]byte("example"), []byte{term},
+			[]byte("www"), []byte{term},
+		)},
+		{"fqdn", "a.b.", []byte{term, 'b', term, 'a', term}},
+		{"dot", ".", []byte{term, term}},
+		{"escaped", "\x00.\x01", []byte{esc, 0x01, term, esc, 0x00, term}},
+	})
+}
+
+func TestDomainNameOrdering(t *testing.T) {
+	t.Parallel()
+	testOrdering(t, lexy.DomainName(), []testCase[string]{
+		{"empty", "", nil},
+		{"com", "com", nil},
+		{"example.com", "example.com", nil},
+		{"api.example.com", "api.example.com", nil},
+		{"v1.api.example.com", "v1.api.example.com", nil},
+		{"www.example.com", "www.example.com", nil},
+		{"example-a.com", "example-a.com", nil},
+		{"examples.com", "examples.com", nil},
+		{"zzz.com", "zzz.com", nil},
+		{"example.net", "example.net", nil},
+		{"example.org", "example.org", nil},
+	})
+}
+
+func TestPathSegments(t *testing.T) {
+	t.Parallel()
+	codec := lexy.PathSegments("/")
+	assert.True(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[string]{
+		{"empty", "", []byte{}},
+		{"root", "/", []byte{term, term}},
+		{"relative", "a", []byte{'a', term}},
+		{"/a/b", "/a/b", []byte{term, 'a', term, 'b', term}},
+		{"/a/", "/a/", []byte{term, 'a', term, term}},
+		{"/a-b", "/a-b", []byte{term, 'a', '-', 'b', term}},
+	})
+	multi := lexy.PathSegments("::")
+	testCodec(t, multi, []testCase[string]{
+		{"a::b:c", "a::b:c", []byte{'a', term, 'b', ':', 'c', term}},
+	})
+	assert.Panics(t, func() {
+		lexy.PathSegments("")
+	})
+}
+
+func TestPathSegmentsOrdering(t *testing.T) {
+	t.Parallel()
+	codec := lexy.PathSegments("/")
+	testOrdering(t, codec, []testCase[string]{
+		{"empty", "", nil},
+		{"root", "/", nil},
+		{"/a", "/a", nil},
+		{"/a/", "/a/", nil},
+		{"/a/b", "/a/b", nil},
+		{"/a/b/c", "/a/b/c", nil},
+		{"/a/c", "/a/c", nil},
+		{"/a-b", "/a-b", nil},
+		{"/ab", "/ab", nil},
+		{"/b", "/b", nil},
+		{"relative", "a", nil},
+		{"relative/a", "a/a", nil},
+		{"relative-b", "a-b", nil},
+	})
+	testOrdering(t, lexy.Terminate(codec), []testCase[string]{
+		{"/a/b", "/a/b", nil},
+		{"/a-b", "/a-b", nil},
+	})
+}
+
+// Returns the values whose encodings have the encoding of prefix as a prefix.
+func prefixScan(codec lexy.Codec[string], prefix string, values []string) []string {
+	prefixBytes := codec.Append(nil, prefix)
+	var keys [][]byte
+	for _, value := range values {
+		keys = append(keys, codec.Append(nil, value))
+	}
+	slices.SortFunc(keys, bytes.Compare)
+	var result []string
+	for _, key := range keys {
+		if bytes.HasPrefix(key, prefixBytes) {
+			value, _ := codec.Get(key)
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func TestSplitPrefixScan(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t,
+		[]string{"example.com", "api.example.com", "v1.api.example.com", "www.example.com"},
+		prefixScan(lexy.DomainName(), "example.com", []string{
+			"www.example.com", "example-a.com", "v1.api.example.com", "example.com",
+			"api.example.com", "example.net", "com", "anexample.com",
+		}))
+	assert.Equal(t,
+		[]string{"/a", "/a/", "/a/b", "/a/b/c"},
+		prefixScan(lexy.PathSegments("/"), "/a", []string{
+			"/a/b/c", "/a-b", "/a", "/ab", "/a/b", "/b/a", "/a/",
+		}))
+}
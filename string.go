@@ -27,3 +27,7 @@ func (stringCodec) Get(buf []byte) (string, []byte) {
 func (stringCodec) RequiresTerminator() bool {
 	return true
 }
+
+func (stringCodec) Describe() Description {
+	return Description{Kind: "string"}
+}
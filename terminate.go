@@ -75,6 +75,18 @@ func (terminatorCodec[T]) RequiresTerminator() bool {
 	return false
 }
 
+func (c terminatorCodec[T]) Describe() Description {
+	d := Describe(c.codec)
+	d.Terminated = true
+	return d
+}
+
+func (c terminatorCodec[T]) format(f *formatState, buf []byte) []byte {
+	encodedValue, buf := termGet(buf)
+	formatPart(f.within(buf, encodedValue, false), c.codec, encodedValue)
+	return buf
+}
+
 var (
 	eByte = []byte{escape}
 	tByte = []byte{terminator}
@@ -55,3 +55,152 @@ func (timeCodec) Get(buf []byte) (time.Time, []byte) {
 func (timeCodec) RequiresTerminator() bool {
 	return false
 }
+
+func (timeCodec) Describe() Description {
+	return Description{Kind: "time"}
+}
+
+// timeUTCCodec is the Codec for time.Time instances, ignoring their locations.
+//
+// Unlike most Codecs, timeUTCCodec is lossy. It does not encode the timezone at all,
+// and Get always returns a time.Time in UTC.
+//
+// A time.Time is encoded as the below values,
+// using the appropriate uint/int Codecs so that the encoded sort order is correct.
+//
+//	int64 seconds since epoch (UTC)
+//	uint32 nanoseconds with the second
+type timeUTCCodec struct{}
+
+func (timeUTCCodec) Append(buf []byte, value time.Time) []byte {
+	seconds, nanos, _ := splitTime(value)
+	//nolint:mnd
+	buf = stdInt64.Append(slices.Grow(buf, 12), seconds)
+	return stdUint32.Append(buf, nanos)
+}
+
+func (timeUTCCodec) Put(buf []byte, value time.Time) []byte {
+	seconds, nanos, _ := splitTime(value)
+	buf = stdInt64.Put(buf, seconds)
+	return stdUint32.Put(buf, nanos)
+}
+
+func (timeUTCCodec) Get(buf []byte) (time.Time, []byte) {
+	seconds, buf := stdInt64.Get(buf)
+	nanos, buf := stdUint32.Get(buf)
+	return time.Unix(seconds, int64(nanos)).UTC(), buf
+}
+
+func (timeUTCCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (timeUTCCodec) Describe() Description {
+	return Description{Kind: "time_utc"}
+}
+
+// truncTimeCodec is the Codec for time.Time instances truncated to a fixed precision.
+//
+// Unlike most Codecs, truncTimeCodec is lossy. It does not encode the timezone at all,
+// and Get always returns a time.Time in UTC.
+// It also discards any precision finer than its unit.
+// Truncation is always towards the past, so the order of encoded instances is preserved,
+// although distinct instances within the same unit will have the same encoding.
+//
+// A time.Time is encoded as an int64 number of units since the epoch (UTC).
+// The result is undefined if that number does not fit in an int64,
+// which can only happen for instances more than 292,000 years from the epoch.
+type truncTimeCodec struct {
+	perSecond int64 // number of units in one second, one of 1, 1e3, or 1e6
+}
+
+const nanosPerSecond = int64(time.Second)
+
+func (c truncTimeCodec) toUnits(value time.Time) int64 {
+	// Nanosecond is never negative, so this truncates towards the past.
+	return value.Unix()*c.perSecond + int64(value.Nanosecond())/(nanosPerSecond/c.perSecond)
+}
+
+func (c truncTimeCodec) fromUnits(units int64) time.Time {
+	seconds, rem := units/c.perSecond, units%c.perSecond
+	if rem < 0 {
+		seconds--
+		rem += c.perSecond
+	}
+	return time.Unix(seconds, rem*(nanosPerSecond/c.perSecond)).UTC()
+}
+
+func (c truncTimeCodec) Append(buf []byte, value time.Time) []byte {
+	return stdInt64.Append(buf, c.toUnits(value))
+}
+
+func (c truncTimeCodec) Put(buf []byte, value time.Time) []byte {
+	return stdInt64.Put(buf, c.toUnits(value))
+}
+
+func (c truncTimeCodec) Get(buf []byte) (time.Time, []byte) {
+	units, buf := stdInt64.Get(buf)
+	return c.fromUnits(units), buf
+}
+
+func (truncTimeCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (c truncTimeCodec) Describe() Description {
+	switch c {
+	case stdTimeSec:
+		return Description{Kind: "time_seconds"}
+	case stdTimeMilli:
+		return Description{Kind: "time_millis"}
+	default:
+		return Description{Kind: "time_micros"}
+	}
+}
+
+// zonedTimeCodec is the Codec for time.Time instances, including the name of their location.
+//
+// A time.Time is encoded as it is by timeCodec,
+// followed by the escaped and terminated name of its location, as returned by time.Time.Location.
+// The order of encoded instances is UTC time first, timezone offset second, and location name third.
+//
+// Get restores the location using time.LoadLocation.
+// If the name cannot be loaded, or the loaded location has a different offset at that instant
+// (for example, because the tz database changed, or a time.FixedZone's name is also an IANA name),
+// Get falls back to a time.FixedZone with the encoded name and offset.
+// The names "UTC" and "Local" are restored as time.UTC and time.Local,
+// and an empty name is always restored as a time.FixedZone.
+type zonedTimeCodec struct{}
+
+func (zonedTimeCodec) Append(buf []byte, value time.Time) []byte {
+	buf = stdTime.Append(buf, value)
+	return stdTermString.Append(buf, value.Location().String())
+}
+
+func (zonedTimeCodec) Put(buf []byte, value time.Time) []byte {
+	buf = stdTime.Put(buf, value)
+	return stdTermString.Put(buf, value.Location().String())
+}
+
+func (zonedTimeCodec) Get(buf []byte) (time.Time, []byte) {
+	instant, buf := stdTime.Get(buf)
+	name, buf := stdTermString.Get(buf)
+	_, offset := instant.Zone()
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			restored := instant.In(loc)
+			if _, restoredOffset := restored.Zone(); restoredOffset == offset {
+				return restored, buf
+			}
+		}
+	}
+	return instant.In(time.FixedZone(name, offset)), buf
+}
+
+func (zonedTimeCodec) RequiresTerminator() bool {
+	return false
+}
+
+func (zonedTimeCodec) Describe() Description {
+	return Description{Kind: "time_zoned"}
+}
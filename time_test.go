@@ -128,3 +128,143 @@ func TestTimeOrdering(t *testing.T) {
 		{"pos Berlin 7", posUTC7.In(locBerlin), nil},
 	})
 }
+
+func TestTimeUTC(t *testing.T) {
+	t.Parallel()
+	codec := lexy.TimeUTC()
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[time.Time]{
+		{"epoch", time.Unix(0, 0).UTC(), []byte{
+			0x80, 0, 0, 0, 0, 0, 0, 0,
+			0, 0, 0, 0,
+		}},
+		{"before epoch", time.Unix(-1, 999_999_999).UTC(), []byte{
+			0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+			0x3B, 0x9A, 0xC9, 0xFF,
+		}},
+		{"after epoch", time.Unix(1, 1).UTC(), []byte{
+			0x80, 0, 0, 0, 0, 0, 0, 1,
+			0, 0, 0, 1,
+		}},
+	})
+	for _, tt := range timeTestCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, _ := codec.Get(codec.Append(nil, tt.value))
+			assert.True(t, tt.value.Equal(got), "round trip")
+			assert.Equal(t, time.UTC, got.Location())
+		})
+	}
+}
+
+func TestTimeTruncated(t *testing.T) {
+	t.Parallel()
+	when := time.Date(2000, 1, 2, 3, 4, 5, 123_456_789, time.UTC)
+	past := time.Date(1900, 1, 2, 3, 4, 5, 123_456_789, time.UTC)
+	for _, tt := range []struct {
+		name  string
+		codec lexy.Codec[time.Time]
+		unit  time.Duration
+	}{
+		{"seconds", lexy.TimeSeconds(), time.Second},
+		{"millis", lexy.TimeMillis(), time.Millisecond},
+		{"micros", lexy.TimeMicros(), time.Microsecond},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.False(t, tt.codec.RequiresTerminator())
+			testCodec(t, tt.codec, fillTestData(tt.codec, []testCase[time.Time]{
+				{"zero", time.Time{}.Truncate(tt.unit), nil},
+				{"past", past.Truncate(tt.unit), nil},
+				{"epoch", time.Unix(0, 0).UTC(), nil},
+				{"before epoch", time.Unix(-1, 0).UTC(), nil},
+				{"present", when.Truncate(tt.unit), nil},
+			}))
+			for _, value := range []time.Time{when, past, when.In(time.FixedZone("", 3600))} {
+				buf := tt.codec.Append(nil, value)
+				assert.Len(t, buf, 8)
+				got, _ := tt.codec.Get(buf)
+				assert.True(t, value.Truncate(tt.unit).Equal(got), "truncated %s to %s", value, got)
+				assert.Equal(t, time.UTC, got.Location())
+			}
+		})
+	}
+}
+
+func TestTimeTruncatedOrdering(t *testing.T) {
+	t.Parallel()
+	for _, codec := range []lexy.Codec[time.Time]{
+		lexy.TimeUTC(),
+		lexy.TimeSeconds(),
+		lexy.TimeMillis(),
+		lexy.TimeMicros(),
+	} {
+		testOrdering(t, codec, []testCase[time.Time]{
+			{"zero", time.Time{}, nil},
+			{"1900", time.Date(1900, 1, 2, 3, 4, 5, 0, time.UTC), nil},
+			{"before epoch", time.Unix(-1, 0), nil},
+			{"epoch", time.Unix(0, 0), nil},
+			{"after epoch", time.Unix(1, 0), nil},
+			{"2000 east", time.Date(2000, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600)), nil},
+			{"2000 UTC", time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC), nil},
+			{"2000 west", time.Date(2000, 1, 2, 3, 4, 5, 0, time.FixedZone("", -3600)), nil},
+		})
+	}
+}
+
+func TestTimeZoned(t *testing.T) {
+	t.Parallel()
+	codec := lexy.TimeZoned()
+	assert.False(t, codec.RequiresTerminator())
+	locNYC, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Not the real offset for America/New_York, so it must be restored as a fixed zone.
+	fakeNYC := time.FixedZone("America/New_York", 3600)
+	when := time.Date(2000, 7, 2, 3, 4, 5, 6, time.UTC)
+
+	for _, tt := range append(timeTestCases(), []testCase[time.Time]{
+		{"utc location", when, nil},
+		{"fixed unnamed", when.In(time.FixedZone("", -3600)), nil},
+		{"fixed named", when.In(time.FixedZone("XYZ", 7200)), nil},
+		{"fake nyc", when.In(fakeNYC), nil},
+		{"bad name", when.In(time.FixedZone("No/Such_Zone\x00\x01", 60)), nil},
+	}...) {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			buf := codec.Append(nil, tt.value)
+			got, rest := codec.Get(buf)
+			assert.Empty(t, rest)
+			assert.True(t, tt.value.Equal(got), "round trip")
+			assert.Equal(t, tt.value.Location().String(), got.Location().String(), "location name")
+			_, expectedOffset := tt.value.Zone()
+			_, actualOffset := got.Zone()
+			assert.Equal(t, expectedOffset, actualOffset, "offsets")
+		})
+	}
+
+	// Loaded and well-known locations are restored exactly.
+	testCodec(t, codec, fillTestData(codec, []testCase[time.Time]{
+		{"utc", when, nil},
+		{"nyc", when.In(locNYC), nil},
+		//nolint:gosmopolitan
+		{"local", when.In(time.Local), nil},
+	}))
+}
+
+func TestTimeZonedOrdering(t *testing.T) {
+	t.Parallel()
+	locNYC, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	locDetroit, err := time.LoadLocation("America/Detroit")
+	require.NoError(t, err)
+	when := time.Date(2000, 1, 2, 3, 4, 5, 6, time.UTC)
+	later := when.Add(time.Nanosecond)
+
+	testOrdering(t, lexy.TimeZoned(), []testCase[time.Time]{
+		{"Detroit", when.In(locDetroit), nil},
+		{"NYC", when.In(locNYC), nil},
+		{"UTC", when, nil},
+		{"later Detroit", later.In(locDetroit), nil},
+		{"later NYC", later.In(locNYC), nil},
+	})
+}
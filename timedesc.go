@@ -0,0 +1,72 @@
+package lexy
+
+import (
+	"time"
+)
+
+// descTimeCodec is the Codec for time.Time instances ordered newest first.
+//
+// The encoding is exactly that of Negate(codec), which descTimeCodec embeds for encoding and decoding.
+// The delegate codec must not require escaping,
+// and must encode the instant first as an order-preserving fixed number of bytes, instantSize.
+// That is true of all the time.Time Codecs in this package.
+// The instant-only prefix is what allows NewerThan and NotOlderThan to ignore any trailing timezone data.
+type descTimeCodec struct {
+	negateCodec[time.Time]
+	instantSize int
+}
+
+// instantPrefix returns the encoded instant of value, without any trailing timezone data.
+func (c descTimeCodec) instantPrefix(value time.Time) []byte {
+	return c.Append(nil, value)[:c.instantSize]
+}
+
+// NewerThan returns the encoded key bound between instants newer than t and instants not newer than t,
+// for a Codec returned by [TimeDesc], [TimeUTCDesc], [TimeSecondsDesc], [TimeMillisDesc], or [TimeMicrosDesc].
+// Instants are compared at the precision of codec, ignoring timezones.
+// NewerThan will panic if codec is not one of those Codecs.
+//
+// Encodings of instants newer than t are less than the bound,
+// and encodings of all other instants are greater than or equal to it.
+// For a range scan over keys in [begin, end), this bound is the end of a scan of everything newer than t,
+// or the beginning of a scan of everything at or before t.
+func NewerThan(codec Codec[time.Time], t time.Time) []byte {
+	c, ok := codec.(descTimeCodec)
+	if !ok {
+		panic(badTypeError{codec})
+	}
+	return c.instantPrefix(t)
+}
+
+// NotOlderThan returns the encoded key bound between instants not older than t and instants older than t,
+// for a Codec returned by [TimeDesc], [TimeUTCDesc], [TimeSecondsDesc], [TimeMillisDesc], or [TimeMicrosDesc].
+// Instants are compared at the precision of codec, ignoring timezones.
+// NotOlderThan will panic if codec is not one of those Codecs.
+//
+// Encodings of instants at or after t are less than the bound,
+// and encodings of all other instants are greater than or equal to it.
+// For a range scan over keys in [begin, end), this bound is the end of a scan of everything at or after t,
+// or the beginning of a scan of everything before t.
+// NotOlderThan returns nil if there is no such bound because t is the oldest encodable instant,
+// in which case the scan should be unbounded.
+func NotOlderThan(codec Codec[time.Time], t time.Time) []byte {
+	c, ok := codec.(descTimeCodec)
+	if !ok {
+		panic(badTypeError{codec})
+	}
+	return successor(c.instantPrefix(t))
+}
+
+// successor returns the smallest byte slice of the same length as buf that is greater than buf,
+// treating buf as a big-endian unsigned integer, or nil if buf is all 0xFF bytes.
+// Every encoding having buf as a prefix is less than the result.
+// The argument is modified in place.
+func successor(buf []byte) []byte {
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i]++
+		if buf[i] != 0 {
+			return buf
+		}
+	}
+	return nil
+}
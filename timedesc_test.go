@@ -0,0 +1,239 @@
+package lexy_test
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+func TestTimeDesc(t *testing.T) {
+	t.Parallel()
+	codec := lexy.TimeDesc()
+	assert.False(t, codec.RequiresTerminator())
+	var testCases []testCase[time.Time]
+	for _, tt := range timeTestCases() {
+		_, offset := tt.value.Zone()
+		value := tt.value.In(time.FixedZone("", offset))
+		testCases = append(testCases, testCase[time.Time]{
+			tt.name,
+			value,
+			negBytes(lexy.Time().Append(nil, value)),
+		})
+	}
+	testCodec(t, codec, testCases)
+}
+
+func TestTimeDescVariants(t *testing.T) {
+	t.Parallel()
+	when := time.Date(2000, 1, 2, 3, 4, 5, 123_456_789, time.UTC)
+	for _, tt := range []struct {
+		name  string
+		codec lexy.Codec[time.Time]
+		asc   lexy.Codec[time.Time]
+	}{
+		{"utc", lexy.TimeUTCDesc(), lexy.TimeUTC()},
+		{"seconds", lexy.TimeSecondsDesc(), lexy.TimeSeconds()},
+		{"millis", lexy.TimeMillisDesc(), lexy.TimeMillis()},
+		{"micros", lexy.TimeMicrosDesc(), lexy.TimeMicros()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.False(t, tt.codec.RequiresTerminator())
+			var testCases []testCase[time.Time]
+			for _, value := range []time.Time{{}, when, time.Unix(-1, 0)} {
+				value, _ = tt.asc.Get(tt.asc.Append(nil, value))
+				testCases = append(testCases, testCase[time.Time]{
+					value.String(),
+					value,
+					negBytes(tt.asc.Append(nil, value)),
+				})
+			}
+			testCodec(t, tt.codec, testCases)
+		})
+	}
+}
+
+func TestDurationDesc(t *testing.T) {
+	t.Parallel()
+	codec := lexy.DurationDesc()
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[time.Duration]{
+		{"0", 0, []byte{0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}},
+		{"1ns", time.Nanosecond, []byte{0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE}},
+		{"-1ns", -time.Nanosecond, []byte{0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+	})
+	testOrdering(t, codec, []testCase[time.Duration]{
+		{"1h", time.Hour, nil},
+		{"1s", time.Second, nil},
+		{"1ns", time.Nanosecond, nil},
+		{"0", 0, nil},
+		{"-1ns", -time.Nanosecond, nil},
+		{"-1h", -time.Hour, nil},
+	})
+}
+
+func TestTimeDescOrdering(t *testing.T) {
+	t.Parallel()
+	// in order from east to west, the reverse of TestTimeOrdering,
+	// UTC is between Berlin and NYC.
+	locFixed := time.FixedZone("fixed", -12*3600)
+	locLA, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	locNYC, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	locBerlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// UTC times in reverse order, pos/neg relative to epoch and 7/6 nanoseconds
+	// test each of these in multiple timezones
+	posUTC7 := time.Date(2000, 1, 2, 3, 4, 5, 7, time.UTC)
+	posUTC6 := time.Date(2000, 1, 2, 3, 4, 5, 6, time.UTC)
+	negUTC7 := time.Date(1900, 1, 2, 3, 4, 5, 7, time.UTC)
+	negUTC6 := time.Date(1900, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	testOrdering(t, lexy.TimeDesc(), []testCase[time.Time]{
+		{"pos Berlin 7", posUTC7.In(locBerlin), nil},
+		{"pos UTC 7", posUTC7, nil},
+		{"pos NYC 7", posUTC7.In(locNYC), nil},
+		{"pos LA 7", posUTC7.In(locLA), nil},
+		{"pos fixed 7", posUTC7.In(locFixed), nil},
+
+		{"pos Berlin 6", posUTC6.In(locBerlin), nil},
+		{"pos UTC 6", posUTC6, nil},
+		{"pos NYC 6", posUTC6.In(locNYC), nil},
+		{"pos LA 6", posUTC6.In(locLA), nil},
+		{"pos fixed 6", posUTC6.In(locFixed), nil},
+
+		{"neg Berlin 7", negUTC7.In(locBerlin), nil},
+		{"neg UTC 7", negUTC7, nil},
+		{"neg NYC 7", negUTC7.In(locNYC), nil},
+		{"neg LA 7", negUTC7.In(locLA), nil},
+		{"neg fixed 7", negUTC7.In(locFixed), nil},
+
+		{"neg Berlin 6", negUTC6.In(locBerlin), nil},
+		{"neg UTC 6", negUTC6, nil},
+		{"neg NYC 6", negUTC6.In(locNYC), nil},
+		{"neg LA 6", negUTC6.In(locLA), nil},
+		{"neg fixed 6", negUTC6.In(locFixed), nil},
+	})
+
+	for _, codec := range []lexy.Codec[time.Time]{
+		lexy.TimeUTCDesc(),
+		lexy.TimeSecondsDesc(),
+		lexy.TimeMillisDesc(),
+		lexy.TimeMicrosDesc(),
+	} {
+		testOrdering(t, codec, []testCase[time.Time]{
+			{"2000", time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC), nil},
+			{"after epoch", time.Unix(1, 0), nil},
+			{"epoch", time.Unix(0, 0), nil},
+			{"before epoch", time.Unix(-1, 0), nil},
+			{"1900", time.Date(1900, 1, 2, 3, 4, 5, 0, time.UTC), nil},
+			{"zero", time.Time{}, nil},
+		})
+	}
+}
+
+// Returns a copy of buf with all bits flipped.
+func negBytes(buf []byte) []byte {
+	result := make([]byte, len(buf))
+	for i, b := range buf {
+		result[i] = ^b
+	}
+	return result
+}
+
+// Scans sorted keys in [begin, end), with nil meaning unbounded, and returns the decoded times.
+func scanTimes(codec lexy.Codec[time.Time], keys [][]byte, begin, end []byte) []time.Time {
+	var result []time.Time
+	for _, key := range keys {
+		if begin != nil && bytes.Compare(key, begin) < 0 {
+			continue
+		}
+		if end != nil && bytes.Compare(key, end) >= 0 {
+			continue
+		}
+		value, _ := codec.Get(key)
+		result = append(result, value)
+	}
+	return result
+}
+
+func TestTimeDescBounds(t *testing.T) {
+	t.Parallel()
+	east := time.FixedZone("", 3600)
+	west := time.FixedZone("", -3600)
+	t0 := time.Date(2000, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+	tBefore := t0.Add(-time.Millisecond)
+	tAfter := t0.Add(time.Millisecond)
+
+	for _, tt := range []struct {
+		name  string
+		codec lexy.Codec[time.Time]
+	}{
+		{"time", lexy.TimeDesc()},
+		{"utc", lexy.TimeUTCDesc()},
+		{"millis", lexy.TimeMillisDesc()},
+		{"micros", lexy.TimeMicrosDesc()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			codec := tt.codec
+			var keys [][]byte
+			for _, value := range []time.Time{
+				tAfter.In(west), tAfter, tAfter.In(east),
+				t0.In(west), t0, t0.In(east),
+				tBefore.In(west), tBefore, tBefore.In(east),
+			} {
+				keys = append(keys, codec.Append(nil, value))
+			}
+			slices.SortFunc(keys, bytes.Compare)
+
+			newer := lexy.NewerThan(codec, t0.In(east))
+			notOlder := lexy.NotOlderThan(codec, t0.In(west))
+			for _, got := range scanTimes(codec, keys, nil, newer) {
+				assert.True(t, got.Equal(tAfter), "newer: %s", got)
+			}
+			for _, got := range scanTimes(codec, keys, newer, nil) {
+				assert.False(t, got.After(t0), "not newer: %s", got)
+			}
+			for _, got := range scanTimes(codec, keys, nil, notOlder) {
+				assert.False(t, got.Before(t0), "not older: %s", got)
+			}
+			for _, got := range scanTimes(codec, keys, notOlder, nil) {
+				assert.True(t, got.Equal(tBefore), "older: %s", got)
+			}
+			assert.NotEmpty(t, scanTimes(codec, keys, newer, notOlder))
+			for _, got := range scanTimes(codec, keys, newer, notOlder) {
+				assert.True(t, got.Equal(t0), "same instant: %s", got)
+			}
+		})
+	}
+}
+
+func TestTimeDescBoundsEdges(t *testing.T) {
+	t.Parallel()
+	codec := lexy.TimeSecondsDesc()
+	oldest, _ := codec.Get([]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF})
+	assert.Nil(t, lexy.NotOlderThan(codec, oldest))
+	assert.Equal(t, []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, lexy.NewerThan(codec, oldest))
+	assert.Equal(t,
+		[]byte{0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF},
+		lexy.NewerThan(codec, time.Unix(0, 999_999_999)))
+	assert.Equal(t,
+		[]byte{0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00},
+		lexy.NotOlderThan(codec, time.Unix(0, 0)))
+
+	assert.Panics(t, func() {
+		lexy.NewerThan(lexy.Time(), time.Unix(0, 0))
+	})
+	assert.Panics(t, func() {
+		lexy.NotOlderThan(lexy.Negate(lexy.Time()), time.Unix(0, 0))
+	})
+}
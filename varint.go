@@ -0,0 +1,60 @@
+package lexy
+
+// Helpers for an order-preserving variable-length encoding of uint64 values,
+// used internally for lengths and counts.
+//
+// A value is encoded as a single byte n, the number of significant bytes in the value (0-8),
+// followed by those n bytes in big-endian order.
+// Zero is encoded as the single byte 0x00.
+// Smaller values have fewer significant bytes, and values with the same number of significant bytes
+// are ordered by their big-endian bytes, so the encoded order is the same as the numeric order.
+// No encoding is a prefix of another, because the first byte determines the length.
+//
+// Examples:
+//
+//	0       -> 00
+//	1       -> 01 01
+//	255     -> 01 FF
+//	256     -> 02 01 00
+//	1 << 63 -> 08 80 00 00 00 00 00 00 00
+
+// uvarintSize returns the number of bytes in the encoding of value.
+func uvarintSize(value uint64) int {
+	n := 1
+	for ; value != 0; value >>= bitsPerByte {
+		n++
+	}
+	return n
+}
+
+func appendUvarint(buf []byte, value uint64) []byte {
+	size := uvarintSize(value) - 1
+	buf = append(buf, byte(size))
+	for i := size - 1; i >= 0; i-- {
+		buf = append(buf, byte(value>>(i*bitsPerByte)))
+	}
+	return buf
+}
+
+func putUvarint(buf []byte, value uint64) []byte {
+	size := uvarintSize(value) - 1
+	_ = buf[size] // check that we have room
+	buf[0] = byte(size)
+	for i := range size {
+		buf[i+1] = byte(value >> ((size - 1 - i) * bitsPerByte))
+	}
+	return buf[size+1:]
+}
+
+func getUvarint(buf []byte) (uint64, []byte) {
+	size := int(buf[0])
+	if size > sizeUint64 {
+		panic(unknownPrefixError{buf[0]})
+	}
+	_ = buf[size] // check that we have enough
+	var value uint64
+	for _, b := range buf[1 : size+1] {
+		value = value<<bitsPerByte | uint64(b)
+	}
+	return value, buf[size+1:]
+}
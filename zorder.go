@@ -0,0 +1,334 @@
+package lexy
+
+import (
+	"bytes"
+	"slices"
+)
+
+// Point2 is a point in two dimensions.
+type Point2[T any] struct {
+	X, Y T
+}
+
+// Point3 is a point in three dimensions.
+type Point3[T any] struct {
+	X, Y, Z T
+}
+
+// zCurve implements the Z-order (Morton order) space-filling curve over coordinates of type T,
+// encoded by coord, which must produce fixed-width encodings of width bytes.
+//
+// A point is encoded by encoding each coordinate with coord, and then interleaving the bits of those encodings,
+// from most significant to least, with the first coordinate's bit first at each bit position.
+// The encoded coordinates are treated as big-endian unsigned integers of width*8 bits,
+// which have the same order as the coordinates themselves.
+// The encoding of a point is dims*width bytes, and points with nearby coordinates tend to have nearby encodings.
+//
+// The z-order bit index k of bit i (counting from the most significant bit) of coordinate d is i*dims + d.
+type zCurve[T any] struct {
+	coord Codec[T]
+	dims  int
+	width int
+}
+
+func newZCurve[T any](coord Codec[T], dims int) zCurve[T] {
+	if coord.RequiresTerminator() {
+		panic(badTypeError{coord})
+	}
+	var zero T
+	return zCurve[T]{coord, dims, len(coord.Append(nil, zero))}
+}
+
+func (z zCurve[T]) size() int {
+	return z.dims * z.width
+}
+
+func getBit(buf []byte, i int) bool {
+	return buf[i/bitsPerByte]&(0x80>>(i%bitsPerByte)) != 0
+}
+
+func setBit(buf []byte, i int, bit bool) {
+	if bit {
+		buf[i/bitsPerByte] |= 0x80 >> (i % bitsPerByte)
+	} else {
+		buf[i/bitsPerByte] &^= 0x80 >> (i % bitsPerByte)
+	}
+}
+
+// encodeCoords returns the fixed-width encodings of values.
+func (z zCurve[T]) encodeCoords(values ...T) [][]byte {
+	coords := make([][]byte, len(values))
+	for d, value := range values {
+		coords[d] = z.coord.Append(nil, value)
+		if len(coords[d]) != z.width {
+			panic(badTypeError{z.coord})
+		}
+	}
+	return coords
+}
+
+// interleave writes the interleaved bits of coords to buf, returning buf following what was written.
+func (z zCurve[T]) interleave(buf []byte, coords [][]byte) []byte {
+	size := z.size()
+	_ = buf[size-1] // check that we have room
+	for i := range z.width * bitsPerByte {
+		for d, coord := range coords {
+			setBit(buf, i*z.dims+d, getBit(coord, i))
+		}
+	}
+	return buf[size:]
+}
+
+// deinterleave returns the encoded coordinates whose bits are interleaved in buf.
+func (z zCurve[T]) deinterleave(buf []byte) [][]byte {
+	_ = buf[z.size()-1] // check that we have enough
+	coords := make([][]byte, z.dims)
+	for d := range coords {
+		coords[d] = make([]byte, z.width)
+	}
+	for i := range z.width * bitsPerByte {
+		for d, coord := range coords {
+			setBit(coord, i, getBit(buf, i*z.dims+d))
+		}
+	}
+	return coords
+}
+
+func (z zCurve[T]) appendPoint(buf []byte, values ...T) []byte {
+	coords := z.encodeCoords(values...)
+	buf = append(buf, make([]byte, z.size())...)
+	z.interleave(buf[len(buf)-z.size():], coords)
+	return buf
+}
+
+func (z zCurve[T]) putPoint(buf []byte, values ...T) []byte {
+	return z.interleave(buf, z.encodeCoords(values...))
+}
+
+// getPoint decodes a point from buf into values, returning buf following what was read.
+func (z zCurve[T]) getPoint(buf []byte, values ...*T) []byte {
+	for d, coord := range z.deinterleave(buf) {
+		*values[d], _ = z.coord.Get(coord)
+	}
+	return buf[z.size():]
+}
+
+// A zBox is a query box, the points whose encoded coordinates are within [lo[d], hi[d]] for every dimension d.
+type zBox struct {
+	lo, hi [][]byte
+}
+
+// splitIndex returns the first z-order bit index at which the encodings of box.lo and box.hi differ,
+// or -1 if box is a single point.
+// Bits before the split index are the same for every point in box.
+func (z zCurve[T]) splitIndex(box zBox) int {
+	for k := range z.size() * bitsPerByte {
+		i, d := k/z.dims, k%z.dims
+		if getBit(box.lo[d], i) != getBit(box.hi[d], i) {
+			return k
+		}
+	}
+	return -1
+}
+
+// isExact returns true if every point between the encodings of box.lo and box.hi is in box.
+// This is true if all bits from the split index onward are 0 in box.lo and 1 in box.hi.
+func (z zCurve[T]) isExact(box zBox) bool {
+	k := z.splitIndex(box)
+	if k < 0 {
+		return true
+	}
+	for ; k < z.size()*bitsPerByte; k++ {
+		i, d := k/z.dims, k%z.dims
+		if getBit(box.lo[d], i) || !getBit(box.hi[d], i) {
+			return false
+		}
+	}
+	return true
+}
+
+// split splits box into two boxes at its split index, whose encoded ranges are in order and do not overlap.
+// The upper bound of the lower box is LITMAX, the greatest point in box less than the split,
+// and the lower bound of the upper box is BIGMIN, the least point in box greater than the split.
+func (z zCurve[T]) split(box zBox) (lower, upper zBox) {
+	k := z.splitIndex(box)
+	i, d := k/z.dims, k%z.dims
+	litMax := append([]byte{}, box.hi[d]...)
+	bigMin := append([]byte{}, box.lo[d]...)
+	setBit(litMax, i, false)
+	setBit(bigMin, i, true)
+	for j := i + 1; j < z.width*bitsPerByte; j++ {
+		setBit(litMax, j, true)
+		setBit(bigMin, j, false)
+	}
+	lower = zBox{box.lo, slices.Clone(box.hi)}
+	lower.hi[d] = litMax
+	upper = zBox{slices.Clone(box.lo), box.hi}
+	upper.lo[d] = bigMin
+	return lower, upper
+}
+
+// bytesToFloat returns buf interpreted as a big-endian unsigned integer, approximated as a float64.
+func bytesToFloat(buf []byte) float64 {
+	var f float64
+	for _, b := range buf {
+		f = f*(1<<bitsPerByte) + float64(b)
+	}
+	return f
+}
+
+// A zCell is a zBox, with cached information about the range of its encodings.
+type zCell struct {
+	box   zBox
+	begin []byte // the encoding of box.lo
+	last  []byte // the encoding of box.hi
+	waste float64
+	exact bool
+}
+
+func (z zCurve[T]) newCell(box zBox) zCell {
+	begin := make([]byte, z.size())
+	last := make([]byte, z.size())
+	z.interleave(begin, box.lo)
+	z.interleave(last, box.hi)
+	return zCell{box, begin, last, z.waste(box, begin, last), z.isExact(box)}
+}
+
+// waste returns an estimate of the number of points between begin and last, the encodings of box.lo and box.hi,
+// which are not in box.
+func (z zCurve[T]) waste(box zBox, begin, last []byte) float64 {
+	volume := 1.0
+	for d := range z.dims {
+		volume *= bytesToFloat(box.hi[d]) - bytesToFloat(box.lo[d]) + 1
+	}
+	return bytesToFloat(last) - bytesToFloat(begin) + 1 - volume
+}
+
+// isAdjacent returns true if the encoded range of b immediately follows the encoded range of a.
+func isAdjacent(a, b zCell) bool {
+	return bytes.Equal(successor(append([]byte{}, a.last...)), b.begin)
+}
+
+// zCover is the coverer for a zCurve, whose cells are the boxes computed by splitting the query box.
+type zCover[T any] struct {
+	curve zCurve[T]
+}
+
+func (zCover[T]) waste(cell zCell) float64 {
+	return cell.waste
+}
+
+func (zCover[T]) splittable(cell zCell) bool {
+	return !cell.exact
+}
+
+func (cv zCover[T]) split(cell zCell) []zCell {
+	lower, upper := cv.curve.split(cell.box)
+	return []zCell{cv.curve.newCell(lower), cv.curve.newCell(upper)}
+}
+
+func (zCover[T]) adjacent(a, b zCell) bool {
+	return isAdjacent(a, b)
+}
+
+// ranges returns at most maxRanges key ranges, in order, covering every point in the box between lo and hi.
+// The ranges may also cover points outside the box, so a range scan must still filter its results.
+//
+// This repeatedly splits the box whose encoded range covers the most points outside of it,
+// using LITMAX and BIGMIN to compute the split boxes, until there are maxRanges ranges,
+// or until every box's encoded range contains only points within the box.
+// Adjacent ranges are merged.
+func (z zCurve[T]) ranges(lo, hi []T, maxRanges int) []KeyRange {
+	box := zBox{z.encodeCoords(lo...), z.encodeCoords(hi...)}
+	for d := range z.dims {
+		if bytes.Compare(box.lo[d], box.hi[d]) > 0 {
+			return nil
+		}
+	}
+	var ranges []KeyRange
+	for _, cell := range coverCells[zCell](zCover[T]{z}, z.newCell(box), maxRanges) {
+		ranges = appendRange(ranges, cell.begin, cell.last)
+	}
+	return ranges
+}
+
+// zOrder2Codec is the Codec for Point2s in Z-order, using a zCurve.
+type zOrder2Codec[T any] struct {
+	curve zCurve[T]
+}
+
+func (c zOrder2Codec[T]) Append(buf []byte, value Point2[T]) []byte {
+	return c.curve.appendPoint(buf, value.X, value.Y)
+}
+
+func (c zOrder2Codec[T]) Put(buf []byte, value Point2[T]) []byte {
+	return c.curve.putPoint(buf, value.X, value.Y)
+}
+
+func (c zOrder2Codec[T]) Get(buf []byte) (Point2[T], []byte) {
+	var value Point2[T]
+	buf = c.curve.getPoint(buf, &value.X, &value.Y)
+	return value, buf
+}
+
+func (zOrder2Codec[T]) RequiresTerminator() bool {
+	return false
+}
+
+func (c zOrder2Codec[T]) Describe() Description {
+	return Description{Kind: "zorder2", Elem: describeRef(c.curve.coord)}
+}
+
+// zOrder3Codec is the Codec for Point3s in Z-order, using a zCurve.
+type zOrder3Codec[T any] struct {
+	curve zCurve[T]
+}
+
+func (c zOrder3Codec[T]) Append(buf []byte, value Point3[T]) []byte {
+	return c.curve.appendPoint(buf, value.X, value.Y, value.Z)
+}
+
+func (c zOrder3Codec[T]) Put(buf []byte, value Point3[T]) []byte {
+	return c.curve.putPoint(buf, value.X, value.Y, value.Z)
+}
+
+func (c zOrder3Codec[T]) Get(buf []byte) (Point3[T], []byte) {
+	var value Point3[T]
+	buf = c.curve.getPoint(buf, &value.X, &value.Y, &value.Z)
+	return value, buf
+}
+
+func (zOrder3Codec[T]) RequiresTerminator() bool {
+	return false
+}
+
+func (c zOrder3Codec[T]) Describe() Description {
+	return Description{Kind: "zorder3", Elem: describeRef(c.curve.coord)}
+}
+
+// ZOrder2Ranges returns at most maxRanges key ranges, in order, which together contain the encodings of
+// every point in the box with corners lo and hi (inclusive), for a Codec returned by [ZOrder2].
+// ZOrder2Ranges will panic if codec is not such a Codec.
+//
+// The ranges may also contain encodings of points outside the box, so results of a range scan must still be filtered.
+// More ranges will contain fewer such points, at the cost of more range scans.
+// The box is repeatedly split at the most significant bit where lo and hi differ,
+// computing the bounds of the two halves in the manner of the LITMAX and BIGMIN algorithms,
+// until there are maxRanges ranges or no range contains any point outside the box.
+// ZOrder2Ranges returns nil if lo is greater than hi in either dimension.
+func ZOrder2Ranges[T any](codec Codec[Point2[T]], lo, hi Point2[T], maxRanges int) []KeyRange {
+	c, ok := codec.(zOrder2Codec[T])
+	if !ok {
+		panic(badTypeError{codec})
+	}
+	return c.curve.ranges([]T{lo.X, lo.Y}, []T{hi.X, hi.Y}, maxRanges)
+}
+
+// ZOrder3Ranges is exactly like [ZOrder2Ranges], but for a Codec returned by [ZOrder3].
+func ZOrder3Ranges[T any](codec Codec[Point3[T]], lo, hi Point3[T], maxRanges int) []KeyRange {
+	c, ok := codec.(zOrder3Codec[T])
+	if !ok {
+		panic(badTypeError{codec})
+	}
+	return c.curve.ranges([]T{lo.X, lo.Y, lo.Z}, []T{hi.X, hi.Y, hi.Z}, maxRanges)
+}
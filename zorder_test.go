@@ -0,0 +1,249 @@
+package lexy_test
+
+import (
+	"bytes"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/phiryll/lexy"
+)
+
+func TestZOrder2(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ZOrder2(lexy.Uint8())
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.Point2[uint8]]{
+		{"origin", lexy.Point2[uint8]{0, 0}, []byte{0x00, 0x00}},
+		{"x max", lexy.Point2[uint8]{0xFF, 0}, []byte{0xAA, 0xAA}},
+		{"y max", lexy.Point2[uint8]{0, 0xFF}, []byte{0x55, 0x55}},
+		{"mixed", lexy.Point2[uint8]{0x0F, 0xF0}, []byte{0x55, 0xAA}},
+		{"max", lexy.Point2[uint8]{0xFF, 0xFF}, []byte{0xFF, 0xFF}},
+	})
+	testCodec(t, lexy.ZOrder2(lexy.Int8()), []testCase[lexy.Point2[int8]]{
+		{"origin", lexy.Point2[int8]{0, 0}, []byte{0xC0, 0x00}},
+		{"(-1, 0)", lexy.Point2[int8]{-1, 0}, []byte{0x6A, 0xAA}},
+	})
+	testCodec(t, lexy.ZOrder2(lexy.Float32()), []testCase[lexy.Point2[float32]]{
+		{"origin", lexy.Point2[float32]{0, 0}, []byte{0xC0, 0, 0, 0, 0, 0, 0, 0}},
+		{"(-Inf, +Inf)", lexy.Point2[float32]{float32(math.Inf(-1)), float32(math.Inf(1))}, []byte{
+			0x55, 0x55, 0x6A, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA,
+		}},
+	})
+}
+
+func TestZOrder3(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ZOrder3(lexy.Uint8())
+	assert.False(t, codec.RequiresTerminator())
+	testCodec(t, codec, []testCase[lexy.Point3[uint8]]{
+		{"origin", lexy.Point3[uint8]{0, 0, 0}, []byte{0x00, 0x00, 0x00}},
+		{"x max", lexy.Point3[uint8]{0xFF, 0, 0}, []byte{0x92, 0x49, 0x24}},
+		{"y max", lexy.Point3[uint8]{0, 0xFF, 0}, []byte{0x49, 0x24, 0x92}},
+		{"z max", lexy.Point3[uint8]{0, 0, 0xFF}, []byte{0x24, 0x92, 0x49}},
+		{"max", lexy.Point3[uint8]{0xFF, 0xFF, 0xFF}, []byte{0xFF, 0xFF, 0xFF}},
+	})
+	testCodec(t, lexy.ZOrder3(lexy.Int64()), []testCase[lexy.Point3[int64]]{
+		{"mixed", lexy.Point3[int64]{-1, 0, math.MaxInt64}, []byte{
+			0x76, 0xDB, 0x6D, 0xB6, 0xDB, 0x6D, 0xB6, 0xDB,
+			0x6D, 0xB6, 0xDB, 0x6D, 0xB6, 0xDB, 0x6D, 0xB6,
+			0xDB, 0x6D, 0xB6, 0xDB, 0x6D, 0xB6, 0xDB, 0x6D,
+		}},
+	})
+}
+
+func TestZOrderOrdering(t *testing.T) {
+	t.Parallel()
+	testOrdering(t, lexy.ZOrder2(lexy.Uint8()), []testCase[lexy.Point2[uint8]]{
+		{"(0, 0)", lexy.Point2[uint8]{0, 0}, nil},
+		{"(0, 1)", lexy.Point2[uint8]{0, 1}, nil},
+		{"(1, 0)", lexy.Point2[uint8]{1, 0}, nil},
+		{"(1, 1)", lexy.Point2[uint8]{1, 1}, nil},
+		{"(0, 2)", lexy.Point2[uint8]{0, 2}, nil},
+		{"(1, 3)", lexy.Point2[uint8]{1, 3}, nil},
+		{"(2, 0)", lexy.Point2[uint8]{2, 0}, nil},
+		{"(3, 3)", lexy.Point2[uint8]{3, 3}, nil},
+		{"(4, 0)", lexy.Point2[uint8]{4, 0}, nil},
+	})
+	testOrdering(t, lexy.ZOrder2(lexy.Float64()), []testCase[lexy.Point2[float64]]{
+		{"(-Inf, -Inf)", lexy.Point2[float64]{math.Inf(-1), math.Inf(-1)}, nil},
+		{"(-1, -1)", lexy.Point2[float64]{-1, -1}, nil},
+		{"(-1, 0)", lexy.Point2[float64]{-1, 0}, nil},
+		{"(0, -1)", lexy.Point2[float64]{0, -1}, nil},
+		{"(0, 0)", lexy.Point2[float64]{0, 0}, nil},
+		{"(1, 1)", lexy.Point2[float64]{1, 1}, nil},
+		{"(+Inf, +Inf)", lexy.Point2[float64]{math.Inf(1), math.Inf(1)}, nil},
+	})
+}
+
+func TestZOrderPanics(t *testing.T) {
+	t.Parallel()
+	assert.Panics(t, func() {
+		lexy.ZOrder2(lexy.String())
+	})
+	assert.Panics(t, func() {
+		lexy.ZOrder3(lexy.Bytes())
+	})
+	// The zero value is nil, which has a different encoded length than non-nil pointers.
+	codec := lexy.ZOrder2(lexy.PointerTo(lexy.Int32()))
+	assert.Panics(t, func() {
+		codec.Append(nil, lexy.Point2[*int32]{ptr(int32(1)), nil})
+	})
+	assert.Panics(t, func() {
+		lexy.ZOrder2Ranges(lexy.Negate(lexy.ZOrder2(lexy.Uint8())), lexy.Point2[uint8]{}, lexy.Point2[uint8]{}, 1)
+	})
+}
+
+// checkRanges checks that ranges are in order, do not overlap, and contain the encoding of every value in inBox,
+// and returns the number of encodings of values not in inBox that are contained in the ranges.
+func checkRanges[T any](t *testing.T, codec lexy.Codec[T], ranges []lexy.KeyRange, values []T, inBox func(T) bool) int {
+	t.Helper()
+	keys := make([][]byte, len(values))
+	for i, value := range values {
+		keys[i] = codec.Append(nil, value)
+	}
+	return checkRangeKeys(t, ranges, keys, func(i int) bool { return inBox(values[i]) })
+}
+
+// checkRangeKeys is like checkRanges, but for already encoded keys.
+func checkRangeKeys(t *testing.T, ranges []lexy.KeyRange, keys [][]byte, inBox func(int) bool) int {
+	t.Helper()
+	for i := 1; i < len(ranges); i++ {
+		require.NotNil(t, ranges[i-1].End)
+		require.Negative(t, bytes.Compare(ranges[i-1].End, ranges[i].Begin), "ranges must not be adjacent or overlap")
+	}
+	extra := 0
+	for k, key := range keys {
+		// The index of the first range beginning after key.
+		i, _ := slices.BinarySearchFunc(ranges, key, func(r lexy.KeyRange, key []byte) int {
+			if bytes.Compare(r.Begin, key) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		contained := i > 0 && ranges[i-1].Contains(key)
+		if inBox(k) {
+			if !contained {
+				require.Fail(t, "key not in ranges", "%X", key)
+			}
+		} else if contained {
+			extra++
+		}
+	}
+	return extra
+}
+
+func allPoint2Uint8() []lexy.Point2[uint8] {
+	var points []lexy.Point2[uint8]
+	for x := range 256 {
+		for y := range 256 {
+			points = append(points, lexy.Point2[uint8]{uint8(x), uint8(y)})
+		}
+	}
+	return points
+}
+
+func TestZOrder2Ranges(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ZOrder2(lexy.Uint8())
+	points := allPoint2Uint8()
+	keys := make([][]byte, len(points))
+	for i, p := range points {
+		keys[i] = codec.Append(nil, p)
+	}
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 10 {
+		x1, x2 := uint8(rng.IntN(256)), uint8(rng.IntN(256))
+		y1, y2 := uint8(rng.IntN(256)), uint8(rng.IntN(256))
+		lo := lexy.Point2[uint8]{min(x1, x2), min(y1, y2)}
+		hi := lexy.Point2[uint8]{max(x1, x2), max(y1, y2)}
+		inBox := func(i int) bool {
+			p := points[i]
+			return lo.X <= p.X && p.X <= hi.X && lo.Y <= p.Y && p.Y <= hi.Y
+		}
+		prevExtra := math.MaxInt
+		for _, maxRanges := range []int{1, 4, 16, 64} {
+			ranges := lexy.ZOrder2Ranges(codec, lo, hi, maxRanges)
+			require.NotEmpty(t, ranges)
+			require.LessOrEqual(t, len(ranges), maxRanges)
+			extra := checkRangeKeys(t, ranges, keys, inBox)
+			require.LessOrEqual(t, extra, prevExtra, "more ranges should not cover more points")
+			prevExtra = extra
+		}
+		// Enough ranges to be exact.
+		ranges := lexy.ZOrder2Ranges(codec, lo, hi, 1000)
+		require.Zero(t, checkRangeKeys(t, ranges, keys, inBox))
+	}
+}
+
+func TestZOrder2RangesExamples(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ZOrder2(lexy.Uint8())
+	// An aligned square is a single exact range.
+	assert.Equal(t, []lexy.KeyRange{{[]byte{0x00, 0x10}, []byte{0x00, 0x20}}},
+		lexy.ZOrder2Ranges(codec, lexy.Point2[uint8]{0, 4}, lexy.Point2[uint8]{3, 7}, 10))
+	// The whole space is unbounded above.
+	assert.Equal(t, []lexy.KeyRange{{[]byte{0x00, 0x00}, nil}},
+		lexy.ZOrder2Ranges(codec, lexy.Point2[uint8]{0, 0}, lexy.Point2[uint8]{0xFF, 0xFF}, 10))
+	// A single point.
+	assert.Equal(t, []lexy.KeyRange{{[]byte{0x00, 0x03}, []byte{0x00, 0x04}}},
+		lexy.ZOrder2Ranges(codec, lexy.Point2[uint8]{1, 1}, lexy.Point2[uint8]{1, 1}, 10))
+	// (1, 0) to (2, 0) is not contiguous: (1, 0) < (1, 1) < (0, 2) < ... < (2, 0).
+	assert.Equal(t, []lexy.KeyRange{{[]byte{0x00, 0x02}, []byte{0x00, 0x09}}},
+		lexy.ZOrder2Ranges(codec, lexy.Point2[uint8]{1, 0}, lexy.Point2[uint8]{2, 0}, 1))
+	assert.Equal(t, []lexy.KeyRange{
+		{[]byte{0x00, 0x02}, []byte{0x00, 0x03}},
+		{[]byte{0x00, 0x08}, []byte{0x00, 0x09}},
+	}, lexy.ZOrder2Ranges(codec, lexy.Point2[uint8]{1, 0}, lexy.Point2[uint8]{2, 0}, 2))
+	// Empty box.
+	assert.Nil(t, lexy.ZOrder2Ranges(codec, lexy.Point2[uint8]{2, 0}, lexy.Point2[uint8]{1, 0}, 10))
+}
+
+func TestZOrder3Ranges(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ZOrder3(lexy.Int8())
+	var points []lexy.Point3[int8]
+	for x := -20; x < 20; x++ {
+		for y := -20; y < 20; y++ {
+			for z := -20; z < 20; z++ {
+				points = append(points, lexy.Point3[int8]{int8(x), int8(y), int8(z)})
+			}
+		}
+	}
+	lo := lexy.Point3[int8]{-5, -3, 2}
+	hi := lexy.Point3[int8]{7, 3, 9}
+	inBox := func(p lexy.Point3[int8]) bool {
+		return lo.X <= p.X && p.X <= hi.X && lo.Y <= p.Y && p.Y <= hi.Y && lo.Z <= p.Z && p.Z <= hi.Z
+	}
+	for _, maxRanges := range []int{0, 1, 8, 32, 1000} {
+		ranges := lexy.ZOrder3Ranges(codec, lo, hi, maxRanges)
+		require.LessOrEqual(t, len(ranges), max(1, maxRanges))
+		extra := checkRanges(t, codec, ranges, points, inBox)
+		if maxRanges == 1000 {
+			require.Zero(t, extra)
+		}
+	}
+}
+
+func TestZOrder2RangesFloat(t *testing.T) {
+	t.Parallel()
+	codec := lexy.ZOrder2(lexy.Float64())
+	var points []lexy.Point2[float64]
+	for x := -10.0; x <= 10.0; x += 0.5 {
+		for y := -10.0; y <= 10.0; y += 0.5 {
+			points = append(points, lexy.Point2[float64]{x, y})
+		}
+	}
+	lo := lexy.Point2[float64]{-2.5, -0.5}
+	hi := lexy.Point2[float64]{3, 7.5}
+	inBox := func(p lexy.Point2[float64]) bool {
+		return lo.X <= p.X && p.X <= hi.X && lo.Y <= p.Y && p.Y <= hi.Y
+	}
+	ranges := lexy.ZOrder2Ranges(codec, lo, hi, 32)
+	require.LessOrEqual(t, len(ranges), 32)
+	checkRanges(t, codec, ranges, points, inBox)
+}